@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// allConcurrency - how many kinds kk all searches at the same time
+const allConcurrency = 4
+
+// allSection - the matches of one kind searched by kk all
+type allSection struct {
+	header  string
+	lines   []string
+	objects []runtime.Object
+	err     error
+}
+
+// allKinds - what kk all searches, in the order the sections are printed
+var allKinds = []struct {
+	kind   string
+	search func(keywords []string) allSection
+}{
+	{"pods", func(keywords []string) allSection {
+		results, err := resources.GetPods(searchOptions, keywords)
+		s := allSection{header: util.PodHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].Pod)
+		}
+		return s
+	}},
+	{"deployments", func(keywords []string) allSection {
+		results, err := resources.GetDeployments(searchOptions, keywords)
+		s := allSection{header: util.DeploymentHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].Deployment)
+		}
+		return s
+	}},
+	{"replicasets", func(keywords []string) allSection {
+		results, err := resources.GetReplicaSets(searchOptions, keywords)
+		s := allSection{header: util.ReplicaSetHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].ReplicaSet)
+		}
+		return s
+	}},
+	{"statefulsets", func(keywords []string) allSection {
+		results, err := resources.GetStatefulSets(searchOptions, keywords)
+		s := allSection{header: util.StatefulsetHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].StatefulSet)
+		}
+		return s
+	}},
+	{"daemonsets", func(keywords []string) allSection {
+		results, err := resources.GetDaemonSets(searchOptions, keywords)
+		s := allSection{header: util.DaemonsetHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].DaemonSet)
+		}
+		return s
+	}},
+	{"jobs", func(keywords []string) allSection {
+		results, err := resources.GetJobs(searchOptions, keywords)
+		s := allSection{header: util.JobHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].Job)
+		}
+		return s
+	}},
+	{"cronjobs", func(keywords []string) allSection {
+		results, err := resources.GetCronJobs(searchOptions, keywords)
+		s := allSection{header: util.CronJobHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].CronJob)
+		}
+		return s
+	}},
+	{"services", func(keywords []string) allSection {
+		results, err := resources.GetServices(searchOptions, keywords)
+		s := allSection{header: util.SvcHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].Match.Highlight(resources.NewServiceDetails(results[i].Service)))
+			s.objects = append(s.objects, &results[i].Service)
+		}
+		return s
+	}},
+	{"ingresses", func(keywords []string) allSection {
+		results, err := resources.GetIngresses(searchOptions, keywords)
+		s := allSection{header: util.IngressHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].Ingress)
+		}
+		return s
+	}},
+	{"configmaps", func(keywords []string) allSection {
+		results, err := resources.GetConfigMaps(searchOptions, keywords)
+		s := allSection{header: util.ConfigMapHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].ConfigMap)
+		}
+		return s
+	}},
+	{"secrets", func(keywords []string) allSection {
+		results, err := resources.GetSecrets(searchOptions, keywords)
+		s := allSection{header: util.SecretHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].Secret)
+		}
+		return s
+	}},
+	{"persistentvolumeclaims", func(keywords []string) allSection {
+		results, err := resources.GetPersistentVolumeClaims(searchOptions, keywords)
+		s := allSection{header: util.PvcHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].PersistentVolumeClaim)
+		}
+		return s
+	}},
+	{"serviceaccounts", func(keywords []string) allSection {
+		results, err := resources.GetServiceAccounts(searchOptions, keywords)
+		s := allSection{header: util.ServiceAccountHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].ServiceAccount)
+		}
+		return s
+	}},
+	{"horizontalpodautoscalers", func(keywords []string) allSection {
+		results, err := resources.GetHPAs(searchOptions, keywords)
+		s := allSection{header: util.HpaHeader, err: err}
+		for i := range results {
+			s.lines = append(s.lines, results[i].StatusLine)
+			s.objects = append(s.objects, &results[i].HPA)
+		}
+		return s
+	}},
+}
+
+var (
+	allCmd = &cobra.Command{
+		Use:   "all [keyword...]",
+		Short: "Search every kind of namespaced resource at once",
+		Long: `searches pods, workloads, services, ingresses, configmaps, secrets and more
+at the same time and prints the matches of each kind in its own section,
+e.g. kk all payment -A`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+			if watchResults {
+				exitOnError(fmt.Errorf("kk all can't be combined with --watch"))
+			}
+			if outputOptions.IsDelimited() {
+				exitOnError(fmt.Errorf("kk all prints a table per kind, -o %s isn't supported", outputOptions.Format))
+			}
+
+			sections := make([]allSection, len(allKinds))
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, allConcurrency)
+			for i := range allKinds {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func(i int) {
+					defer wg.Done()
+					defer func() { <-sem }()
+					sections[i] = allKinds[i].search(keywords)
+				}(i)
+			}
+			wg.Wait()
+
+			failed := false
+			var objects []runtime.Object
+			for _, section := range sections {
+				if section.err != nil {
+					failed = true
+					continue
+				}
+				objects = append(objects, section.objects...)
+			}
+
+			// one document, count or summary for everything that matched
+			if outputOptions.IsMachine() || outputOptions.IsAggregate() {
+				for i, section := range sections {
+					if section.err != nil {
+						fmt.Fprintf(os.Stderr, "Error: %s: %v\n", allKinds[i].kind, section.err)
+					}
+				}
+				printResults("", nil, objects)
+			} else {
+				printAllSections(sections)
+			}
+			if failed {
+				exit(exitError)
+			}
+		},
+	}
+)
+
+// printAllSections - print the table of every kind that matched or failed
+// under its name
+func printAllSections(sections []allSection) {
+	printed := false
+	for i, section := range sections {
+		if section.err == nil && len(section.lines) == 0 {
+			continue
+		}
+		if printed {
+			fmt.Println()
+		}
+		printed = true
+		fmt.Printf("-------- %s --------\n", allKinds[i].kind)
+		if section.err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", allKinds[i].kind, section.err)
+			continue
+		}
+		printResults(section.header, section.lines, section.objects)
+	}
+	if !printed {
+		printResults("", nil, nil)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(allCmd)
+}
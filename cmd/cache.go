@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/util"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the list cache enabled with --cache-ttl",
+		// the cache is managed without talking to a cluster
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	}
+
+	cacheClearCmd = &cobra.Command{
+		Use:   "clear",
+		Short: "Remove every cached list result",
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(util.ClearCache())
+			dir, err := util.CacheDir()
+			exitOnError(err)
+			fmt.Printf("Cleared %s\n", dir)
+		},
+	}
+)
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
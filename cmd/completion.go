@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/mateo1647/kk/pkg/client"
+	"github.com/mateo1647/kk/util"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCacheTTL - how long names listed for completion are reused, so
+// every tab doesn't cost a round trip to the API server
+const completionCacheTTL = 20 * time.Second
+
+// bashCompletionFunc - asks `kk __complete` for the names of the resource
+// the command searches, passing on the namespace and context typed so far
+const bashCompletionFunc = `
+__kk_override_flags()
+{
+    local flags=() word prev=""
+    for word in "${words[@]}"; do
+        case "${prev}" in
+            -n|--namespace)
+                flags+=("--namespace=${word}")
+                ;;
+            --context|--kubeconfig)
+                flags+=("${prev}=${word}")
+                ;;
+        esac
+        case "${word}" in
+            --namespace=*|--context=*|--kubeconfig=*|-A|--all-namespaces)
+                flags+=("${word}")
+                ;;
+        esac
+        prev="${word}"
+    done
+    echo "${flags[@]}"
+}
+
+__kk_complete()
+{
+    local out
+    if out=$(kk __complete "$@" $(__kk_override_flags) 2>/dev/null); then
+        COMPREPLY=( $(compgen -W "${out[*]}" -- "$cur") )
+    fi
+}
+
+__kk_get_namespaces()
+{
+    __kk_complete namespace
+}
+
+__kk_get_contexts()
+{
+    local out
+    if out=$(kk __complete --contexts 2>/dev/null); then
+        COMPREPLY=( $(compgen -W "${out[*]}" -- "$cur") )
+    fi
+}
+
+__kk_custom_func()
+{
+    case ${last_command} in
+        kk_get)
+            if [[ ${#nouns[@]} -ne 0 ]]; then
+                __kk_complete get "${nouns[0]}"
+            fi
+            ;;
+        kk_completion | kk_cache | kk_cache_clear)
+            ;;
+        *)
+            __kk_complete "${last_command#kk_}"
+            ;;
+    esac
+}
+`
+
+var (
+	completeContexts bool
+
+	completionCmd = &cobra.Command{
+		Use:   "completion <bash|zsh>",
+		Short: "Print a shell completion script",
+		Long: `prints a completion script for bash or zsh, e.g.
+source <(kk completion bash)
+in bash, resource names complete from the cluster, e.g. kk pod <TAB>`,
+		Args: cobra.ExactArgs(1),
+		// the script is generated without talking to a cluster
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		Run: func(cmd *cobra.Command, args []string) {
+			switch args[0] {
+			case "bash":
+				exitOnError(rootCmd.GenBashCompletion(os.Stdout))
+			case "zsh":
+				exitOnError(rootCmd.GenZshCompletion(os.Stdout))
+			default:
+				exitOnError(fmt.Errorf("unsupported shell %q, expected one of: bash|zsh", args[0]))
+			}
+		},
+	}
+
+	completeCmd = &cobra.Command{
+		Use:    "__complete <command> [resource]",
+		Short:  "List names for shell completion",
+		Hidden: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if !completeContexts {
+				rootCmd.PersistentPreRun(cmd, args)
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if completeContexts {
+				names, err := client.Contexts(util.ClientOptions(searchOptions))
+				exitOnError(err)
+				printNames(names)
+				return
+			}
+			if len(args) == 0 {
+				return
+			}
+
+			resource := args[0]
+			if found, _, err := rootCmd.Find(args[:1]); err == nil && found != rootCmd {
+				resource = found.Name()
+			}
+			if resource == "get" {
+				if len(args) < 2 {
+					return
+				}
+				resource = args[1]
+			}
+
+			gvr, namespaced, err := util.ClientFor(searchOptions).ResolveResource(resource)
+			exitOnError(err)
+			if searchOptions.CacheTTL == 0 {
+				searchOptions.CacheTTL = completionCacheTTL
+			}
+			list, err := util.ClientFor(searchOptions).DynamicList(searchOptions, gvr, namespaced)
+			exitOnError(err)
+
+			seen := map[string]bool{}
+			var names []string
+			for _, item := range list.Items {
+				if name := item.GetName(); !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			printNames(names)
+		},
+	}
+)
+
+// printNames - one completion candidate per line
+func printNames(names []string) {
+	for _, name := range names {
+		fmt.Println(name)
+	}
+}
+
+func init() {
+	completeCmd.Flags().BoolVar(&completeContexts, "contexts", false,
+		"List the kubeconfig contexts instead.")
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(completeCmd)
+	rootCmd.BashCompletionFunction = bashCompletionFunc
+}
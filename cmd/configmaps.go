@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configMapCmd = &cobra.Command{
+		Use:     "configmap",
+		Aliases: []string{"configmaps", "cm"},
+		Short:   "Search configmaps by name, key or value",
+		Long: `lists matching configmaps with how many keys they hold; --key and
+--value-contains find the configmaps holding a key or value and list the keys that matched`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			header := util.ConfigMapHeader
+			if searchOptions.FiltersData() {
+				header = util.ConfigMapKeyHeader
+				if searchOptions.ShowValues {
+					header = util.ConfigMapValueHeader
+				}
+			}
+
+			runOrWatch("configmaps", func(opt *options.SearchOptions) (table, error) {
+				configMapResults, err := resources.GetConfigMaps(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var rowObjects, objects []runtime.Object
+				for i := range configMapResults {
+					if opt.FiltersData() {
+						for _, line := range resources.NewConfigMapKeyDetails(configMapResults[i].ConfigMap, configMapResults[i].Keys, opt.ShowValues) {
+							lines = append(lines, configMapResults[i].Match.Highlight(line))
+							rowObjects = append(rowObjects, &configMapResults[i].ConfigMap)
+						}
+					} else {
+						lines = append(lines, configMapResults[i].StatusLine)
+						rowObjects = append(rowObjects, &configMapResults[i].ConfigMap)
+					}
+					objects = append(objects, &configMapResults[i].ConfigMap)
+				}
+				return table{header: header, lines: lines, rowObjects: rowObjects, objects: objects}, nil
+			})
+		},
+	}
+)
+
+// addDataFlags - the flags filtering configmaps and secrets on their data
+func addDataFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&searchOptions.DataKey, "key", "",
+		"Only show the keys with this name, and the objects holding one, e.g. --key=DATABASE_URL.")
+	cmd.Flags().StringVar(&searchOptions.ValueContains, "value-contains", "",
+		"Only show the keys whose value contains this text, and the objects holding one, e.g. --value-contains=postgres.")
+}
+
+func init() {
+	addDataFlags(configMapCmd)
+	configMapCmd.Flags().BoolVar(&searchOptions.ShowValues, "show-values", false,
+		"With --key or --value-contains, print the value of each matching key instead of its size. Values that aren't valid UTF-8 are shown as <binary: N bytes>.")
+	rootCmd.AddCommand(configMapCmd)
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/mateo1647/kk/pkg/client"
+	"github.com/mateo1647/kk/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// searchContexts and allContexts - the kubeconfig contexts to search at once
+	searchContexts []string
+	allContexts    bool
+)
+
+// singleContextCommands - commands that act on one cluster and can't be run
+// against several contexts at once
+var singleContextCommands = []string{"all", "doctor", "exec", "logs", "describe", "completion", "__complete", "cache", "clear"}
+
+// isMultiContext - report whether the search runs against several contexts
+func isMultiContext() bool {
+	return allContexts || len(searchContexts) > 0
+}
+
+// validateContexts - reject what can't be merged across contexts before
+// anything is run
+func validateContexts(cmd *cobra.Command) error {
+	if !isMultiContext() {
+		return nil
+	}
+	if contains(singleContextCommands, cmd.Name()) {
+		return fmt.Errorf("kk %s can't be used with --contexts or --all-contexts", cmd.Name())
+	}
+	if searchOptions.Context != "" {
+		return fmt.Errorf("--context can't be combined with --contexts or --all-contexts")
+	}
+	if watchResults {
+		return fmt.Errorf("--watch can't be combined with --contexts or --all-contexts")
+	}
+	if outputOptions.IsAggregate() {
+		return fmt.Errorf("--count and --summary can't be combined with --contexts or --all-contexts")
+	}
+	if printKubectl {
+		return fmt.Errorf("--print-kubectl can't be combined with --contexts or --all-contexts")
+	}
+	switch outputOptions.Format {
+	case "", "wide", "csv", "tsv":
+		return nil
+	}
+	return fmt.Errorf("-o %s can't be combined with --contexts or --all-contexts, use the table, wide, csv or tsv output", outputOptions.Format)
+}
+
+// contextResult - what the search found in one context
+type contextResult struct {
+	header string
+	lines  []string
+	err    error
+}
+
+// runContexts - run search once per context, each with its own client and
+// at the same time, and print the rows of all of them in one table with a
+// CONTEXT column. A context that fails is reported without taking the
+// others down. It exits when done.
+func runContexts(search searchFunc) {
+	contexts := searchContexts
+	if allContexts {
+		var err error
+		contexts, err = client.Contexts(util.ClientOptions(searchOptions))
+		exitOnError(err)
+	}
+
+	results := make([]contextResult, len(contexts))
+	var wg sync.WaitGroup
+	for i, context := range contexts {
+		wg.Add(1)
+		go func(i int, context string) {
+			defer wg.Done()
+			results[i] = searchContext(context, search)
+		}(i, context)
+	}
+	wg.Wait()
+
+	header := ""
+	var lines []string
+	failed := false
+	for i, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(os.Stderr, "Error: context %s: %v\n", contexts[i], result.err)
+			failed = true
+			continue
+		}
+		if header == "" {
+			header = result.header
+		}
+		for _, line := range result.lines {
+			lines = append(lines, contexts[i]+"\t"+line)
+		}
+	}
+	header = util.ContextColumn + "\t" + header
+
+	switch {
+	case outputOptions.IsDelimited():
+		comma := ','
+		if outputOptions.Format == "tsv" {
+			comma = '\t'
+		}
+		exitOnError(util.PrintDelimited(os.Stdout, comma, header, lines))
+	case len(lines) == 0:
+		fmt.Println("No resources found.")
+	default:
+		util.PrintTable(header, lines)
+	}
+
+	if failed {
+		exit(exitError)
+	}
+	if len(lines) == 0 {
+		exit(exitNoMatch)
+	}
+	exit(exitMatched)
+}
+
+// searchContext - run search against one context, with the columns asked
+// for added to its rows
+func searchContext(context string, search searchFunc) contextResult {
+	opt := *searchOptions
+	opt.Context = context
+	if err := util.InitContextClient(&opt); err != nil {
+		return contextResult{err: err}
+	}
+	if err := util.ClientFor(&opt).CheckNamespaces(&opt); err != nil {
+		return contextResult{err: err}
+	}
+	t, err := search(&opt)
+	if err != nil {
+		return contextResult{err: err}
+	}
+	rowObjects := t.rowObjects
+	if rowObjects == nil {
+		rowObjects = t.objects
+	}
+	header, lines := extendRows(t.header, t.lines, rowObjects)
+	return contextResult{header: header, lines: lines}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringSliceVar(
+		&searchContexts, "contexts", nil,
+		"Comma separated kubeconfig contexts to search at once, e.g. --contexts=prod-us,prod-eu. Rows are prefixed with their context.")
+	rootCmd.PersistentFlags().BoolVar(
+		&allContexts, "all-contexts", false,
+		"If present, search every context of the kubeconfig at once.")
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonSetCmd = &cobra.Command{
+		Use:     "daemonset",
+		Aliases: []string{"daemonsets", "ds"},
+		Short:   "Search daemonsets by name",
+		Long:    `lists daemonsets with their desired, current, ready, up-to-date and available pods`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("daemonsets", func(opt *options.SearchOptions) (table, error) {
+				daemonSetResults, err := resources.GetDaemonSets(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				header := util.DaemonsetHeader
+				if outputOptions.IsWide() {
+					header = util.DaemonsetHeaderWide
+				}
+				for i := range daemonSetResults {
+					line := daemonSetResults[i].StatusLine
+					if outputOptions.IsWide() {
+						line = daemonSetResults[i].Match.Highlight(resources.NewDaemonSetDetailsWide(daemonSetResults[i].DaemonSet))
+					}
+					lines = append(lines, line)
+					objects = append(objects, &daemonSetResults[i].DaemonSet)
+				}
+				return table{header: header, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	daemonSetCmd.Flags().StringVar(&searchOptions.Image, "image", "",
+		"Only show daemonsets with a container whose image contains this text, e.g. --image=fluentd.")
+	daemonSetCmd.Flags().BoolVar(&searchOptions.NotReady, "not-ready", false,
+		"If present, only show daemonsets whose pod isn't ready on every node it should run on.")
+	rootCmd.AddCommand(daemonSetCmd)
+}
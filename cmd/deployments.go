@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	deploymentCmd = &cobra.Command{
+		Use:     "deployment",
+		Aliases: []string{"deployments", "deploy"},
+		Short:   "Search deployments by name",
+		Long:    `lists deployments with their desired, current, up-to-date and available replicas`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("deployments", func(opt *options.SearchOptions) (table, error) {
+				deploymentResults, err := resources.GetDeployments(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				header := util.DeploymentHeader
+				// the wide table has an images column already
+				addImages := showImages && !outputOptions.IsWide()
+				if outputOptions.IsWide() {
+					header = util.DeploymentHeaderWide
+				}
+				if addImages {
+					header += "\t" + util.ImagesColumn
+				}
+				for i := range deploymentResults {
+					line := deploymentResults[i].StatusLine
+					if outputOptions.IsWide() {
+						line = deploymentResults[i].Match.Highlight(resources.NewDeploymentDetailsWide(deploymentResults[i].Deployment))
+					}
+					if addImages {
+						line += "\t" + resources.ContainerImages(deploymentResults[i].Deployment.Spec.Template.Spec)
+					}
+					lines = append(lines, line)
+					objects = append(objects, &deploymentResults[i].Deployment)
+				}
+				return table{header: header, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	deploymentCmd.Flags().BoolVar(&showImages, "show-images", false,
+		"If present, add a column with the image of every container in the pod template.")
+	deploymentCmd.Flags().StringVar(&searchOptions.Image, "image", "",
+		"Only show deployments with a container whose image contains this text, e.g. --image=nginx:1.19.")
+	rootCmd.AddCommand(deploymentCmd)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	describeCmd = &cobra.Command{
+		Use:   "describe <resource> [keyword...]",
+		Short: "Describe the objects matching a search",
+		Long: `runs kubectl describe on every object of the resource matching the search,
+each under a separator naming it, e.g. kk describe pod api`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args[1:])
+
+			gvr, namespaced, err := util.ClientFor(searchOptions).ResolveResource(args[0])
+			exitOnError(err)
+			results, err := resources.GetResources(searchOptions, keywords, gvr, namespaced)
+			exitOnError(err)
+			recordResults(len(results))
+			if len(results) == 0 {
+				fmt.Println("No resources found.")
+				return
+			}
+
+			// kubectl takes the resource qualified with its group, e.g. deployments.apps
+			resource := gvr.Resource
+			if gvr.Group != "" {
+				resource += "." + gvr.Group
+			}
+			failed := false
+			for i := range results {
+				obj := results[i].Object
+				if len(results) > 1 {
+					name := obj.GetName()
+					if namespaced {
+						name = obj.GetNamespace() + "/" + name
+					}
+					if i > 0 {
+						fmt.Println()
+					}
+					fmt.Printf("-------- %s %s --------\n", obj.GetKind(), name)
+				}
+				output, err := util.ClientFor(searchOptions).RawK8sOutput(obj.GetNamespace(), searchOptions.Context, "", searchOptions.Kubeconfig, searchOptions.Timeout, "describe", resource, obj.GetName())
+				if err != nil {
+					// describe the others, the exit code tells one of them failed
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					failed = true
+					continue
+				}
+				for _, line := range output {
+					fmt.Println(line)
+				}
+			}
+			if failed {
+				exit(exitError)
+			}
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+}
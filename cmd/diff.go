@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// diffFunc - compare the objects of a kind matching keywords in the namespace
+// searched with the ones in against
+type diffFunc func(opt *options.SearchOptions, against string, keywords []string) ([]resources.GetDiffResponse, error)
+
+// diffKinds - the kinds kk diff compares, by every name they can be given as
+var diffKinds = map[string]diffFunc{
+	"configmap":  resources.DiffConfigMaps,
+	"configmaps": resources.DiffConfigMaps,
+	"cm":         resources.DiffConfigMaps,
+	"secret":     resources.DiffSecrets,
+	"secrets":    resources.DiffSecrets,
+}
+
+var (
+	// diffAgainst - the namespace kk diff compares the one searched with
+	diffAgainst string
+	// showUnchanged - also list the objects that are the same in both
+	showUnchanged bool
+
+	diffCmd = &cobra.Command{
+		Use:   "diff <configmaps|secrets> [keyword...]",
+		Short: "Compare configmaps or secrets between two namespaces",
+		Long: `lists the configmaps or secrets only in the namespace searched (added), only in the
+--against namespace (removed), or in both with different data (changed), with the keys that differ,
+e.g. kk diff cm -n staging --against prod. Secret values are compared but never printed`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			diff, ok := diffKinds[args[0]]
+			if !ok {
+				exitOnError(fmt.Errorf("kk diff compares configmaps or secrets, not %q", args[0]))
+			}
+			keywords := searchKeywords(args[1:])
+			exitOnError(validateDiff())
+
+			runSearch(func(opt *options.SearchOptions) (table, error) {
+				against, err := diffNamespace(opt)
+				if err != nil {
+					return table{}, err
+				}
+				results, err := diff(opt, against, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range results {
+					if results[i].Change == resources.DiffUnchanged && !showUnchanged {
+						continue
+					}
+					lines = append(lines, results[i].StatusLine)
+					objects = append(objects, results[i].Object)
+				}
+				return table{header: util.DiffHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+// validateDiff - fail on flags kk diff can't be used with before anything is
+// queried
+func validateDiff() error {
+	if diffAgainst == "" {
+		return fmt.Errorf("--against is required, e.g. kk diff cm -n staging --against prod")
+	}
+	if searchOptions.AllNamespaces {
+		return fmt.Errorf("kk diff compares two namespaces and can't be used with --all-namespaces")
+	}
+	if watchResults {
+		return fmt.Errorf("kk diff can't be used with --watch")
+	}
+	return nil
+}
+
+// diffNamespace - the namespace opt is compared with, --against resolved
+// like -n, checking there are exactly two different namespaces to compare
+func diffNamespace(opt *options.SearchOptions) (string, error) {
+	c := util.ClientFor(opt)
+	namespaces, _ := c.SetOptions(opt)
+	if len(namespaces) != 1 {
+		return "", fmt.Errorf("kk diff compares a single namespace with --against, got -n %v", namespaces)
+	}
+	against := *opt
+	against.Namespaces = []string{diffAgainst}
+	if err := c.CheckNamespaces(&against); err != nil {
+		return "", err
+	}
+	if against.Namespaces[0] == namespaces[0] {
+		return "", fmt.Errorf("--against has to be another namespace than %q", namespaces[0])
+	}
+	return against.Namespaces[0], nil
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffAgainst, "against", "",
+		"The namespace to compare the one searched with, e.g. --against=prod.")
+	diffCmd.Flags().BoolVar(&showUnchanged, "show-unchanged", false,
+		"If present, also list the objects whose data is the same in both namespaces.")
+	rootCmd.AddCommand(diffCmd)
+}
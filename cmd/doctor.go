@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/mateo1647/kk/pkg/client"
+	"github.com/mateo1647/kk/util"
+
+	"github.com/spf13/cobra"
+)
+
+// doctorCheck - a resource kk searches that the user should be allowed to
+// list. Without the critical ones kk can't be used at all.
+type doctorCheck struct {
+	group      string
+	resource   string
+	namespaced bool
+	critical   bool
+}
+
+// doctorChecks - the resources kk doctor checks access to
+var doctorChecks = []doctorCheck{
+	{"", "pods", true, true},
+	{"apps", "deployments", true, false},
+	{"", "services", true, false},
+	{"", "configmaps", true, false},
+	{"", "secrets", true, false},
+	{"", "events", true, false},
+	{"", "namespaces", false, false},
+	{"", "nodes", false, false},
+}
+
+// the results of a check, and the colors they are printed in
+const (
+	doctorPass = "PASS"
+	doctorWarn = "WARN"
+	doctorFail = "FAIL"
+)
+
+var doctorColors = map[string]*color.Color{
+	doctorPass: color.New(color.FgGreen),
+	doctorWarn: color.New(color.FgYellow),
+	doctorFail: color.New(color.FgRed),
+}
+
+var (
+	doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that kk can reach and search the cluster",
+		Long: `checks that the kubeconfig loads, the API server of the context answers,
+the resources kk searches may be listed in every namespace searched and the metrics API is installed, with
+hints on fixing what fails. Exits with 2 if kk can't search the cluster at all.`,
+		// the client is what's being checked, it must not fail before the checks
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			exitOnError(util.SetLogFormat(logFormat))
+			exitOnError(util.SetLogLevel(logLevel))
+			raiseLogLevel(verbosity)
+			exitOnError(initConfig(cmd.Flags()))
+			exitOnError(validateContexts(cmd))
+			if outputOptions.NoColor {
+				util.DisableColor()
+			}
+			if quiet {
+				exitOnError(util.DiscardOutput())
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if !runDoctor() {
+				exit(exitError)
+			}
+		},
+	}
+)
+
+// runDoctor - print a line per check, returning false if a critical one failed
+func runDoctor() bool {
+	clientOptions := util.ClientOptions(searchOptions)
+	if _, err := client.RestConfig(clientOptions); err != nil {
+		printCheck(doctorFail, "kubeconfig", err.Error(),
+			"set --kubeconfig or $KUBECONFIG to a valid file, and --context to one of its contexts")
+		return false
+	}
+	context := searchOptions.Context
+	if raw, err := client.ClientConfig(clientOptions).RawConfig(); err == nil && context == "" {
+		context = raw.CurrentContext
+	}
+	printCheck(doctorPass, "kubeconfig", fmt.Sprintf("loaded, using context %q", context), "")
+
+	if err := util.InitClient(searchOptions); err != nil {
+		printCheck(doctorFail, "API server", err.Error(), "check the cluster and user set on the context")
+		return false
+	}
+	c := util.ClientFor(searchOptions)
+	version, err := c.ServerVersion()
+	if err != nil {
+		printCheck(doctorFail, "API server", err.Error(),
+			"check the server address of the context is reachable from here, e.g. over the VPN, and its credentials haven't expired")
+		return false
+	}
+	printCheck(doctorPass, "API server", "reachable, "+version, "")
+
+	ok := true
+	// namespaced resources are checked in every namespace searched
+	namespaces, _ := c.SetOptions(searchOptions)
+	for _, check := range doctorChecks {
+		checked := namespaces
+		if !check.namespaced {
+			checked = []string{""}
+		}
+		for _, ns := range checked {
+			scope := fmt.Sprintf("in namespace %q", ns)
+			switch {
+			case !check.namespaced:
+				scope = "cluster-wide"
+			case ns == "":
+				scope = "in every namespace"
+			}
+			name := "list " + check.resource
+			allowed, reason, err := c.CanList(ns, check.group, check.resource)
+			switch {
+			case err != nil:
+				printCheck(doctorWarn, name, err.Error(), "")
+			case allowed:
+				printCheck(doctorPass, name, "allowed "+scope, "")
+			default:
+				status := doctorWarn
+				if check.critical {
+					status, ok = doctorFail, false
+				}
+				message := "forbidden " + scope
+				if reason != "" {
+					message += ": " + reason
+				}
+				hint := fmt.Sprintf("searches for %s will come back empty; ask a cluster admin for a ClusterRole granting list on %s", check.resource, check.resource)
+				if check.namespaced {
+					hint = fmt.Sprintf("searches for %s will come back empty; ask a cluster admin for a Role granting list on %s, or search another namespace with -n", check.resource, check.resource)
+				}
+				printCheck(status, name, message, hint)
+			}
+		}
+	}
+
+	hasMetrics, err := c.HasMetricsAPI()
+	switch {
+	case err != nil:
+		printCheck(doctorWarn, "metrics API", err.Error(), "")
+	case hasMetrics:
+		printCheck(doctorPass, "metrics API", "available", "")
+	default:
+		printCheck(doctorWarn, "metrics API", "not available", "kk top needs metrics-server installed in the cluster")
+	}
+	return ok
+}
+
+// printCheck - print the result of a check, with how to fix it on the next line
+func printCheck(status, name, message, hint string) {
+	fmt.Printf("%s  %-18s %s\n", doctorColors[status].Sprint(status), name, message)
+	if hint != "" {
+		fmt.Printf("      %-18s hint: %s\n", "", hint)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
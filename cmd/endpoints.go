@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	endpointsCmd = &cobra.Command{
+		Use:     "endpoints",
+		Aliases: []string{"endpoint", "ep"},
+		Short:   "Search endpoints by service name",
+		Long:    `lists the ready and not ready addresses backing each matching service`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("endpoints", func(opt *options.SearchOptions) (table, error) {
+				endpointsResults, err := resources.GetEndpoints(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range endpointsResults {
+					lines = append(lines, endpointsResults[i].StatusLine)
+					objects = append(objects, &endpointsResults[i].Endpoints)
+				}
+				return table{header: util.EndpointsHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	endpointSliceCmd = &cobra.Command{
+		Use:     "endpointslice",
+		Aliases: []string{"endpointslices", "eps"},
+		Short:   "Search endpoint slices by name or service name",
+		Long:    `lists endpoint slices with their service, ports and how many endpoints are ready`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("endpointslices", func(opt *options.SearchOptions) (table, error) {
+				sliceResults, err := resources.GetEndpointSlices(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range sliceResults {
+					lines = append(lines, sliceResults[i].StatusLine)
+					objects = append(objects, &sliceResults[i].EndpointSlice)
+				}
+				return table{header: util.EndpointSliceHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(endpointsCmd)
+	rootCmd.AddCommand(endpointSliceCmd)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventKind string
+	eventType string
+
+	eventCmd = &cobra.Command{
+		Use:     "events",
+		Aliases: []string{"event", "ev"},
+		Short:   "Search events by the name of the object they involve",
+		Long:    `lists events whose involved object name matches the keyword, most recently seen first`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("events", func(opt *options.SearchOptions) (table, error) {
+				eventResults, err := resources.GetEvents(opt, keywords, eventKind, eventType)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range eventResults {
+					lines = append(lines, eventResults[i].StatusLine)
+					objects = append(objects, &eventResults[i].Event)
+				}
+				return table{header: util.EventHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	eventCmd.Flags().StringVar(&eventKind, "kind", "",
+		"Only show events about objects of this kind. (e.g. Pod, Deployment)")
+	eventCmd.Flags().StringVar(&eventType, "type", "",
+		"Only show events of this type. One of: Normal|Warning")
+	eventCmd.Flags().StringVar(&searchOptions.Since, "since", "",
+		"Only show events last seen within this long, e.g. --since=10m during an incident.")
+	rootCmd.AddCommand(eventCmd)
+}
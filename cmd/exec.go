@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"github.com/mattn/go-isatty"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultExecCommand - what kk exec runs when no command is given after --
+var defaultExecCommand = []string{"sh"}
+
+var (
+	execCmd = &cobra.Command{
+		Use:   "exec <search> [-- command...]",
+		Short: "Run a command in the pod matching a search",
+		Long: `runs the command, a shell by default, in the one pod matching the
+search through kubectl exec; when several pods match, they are listed instead`,
+		Run: func(cmd *cobra.Command, args []string) {
+			command := defaultExecCommand
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				if len(args) > dash {
+					command = args[dash:]
+				}
+				args = args[:dash]
+			}
+			keywords := searchKeywords(args)
+
+			podResults, err := resources.GetPods(searchOptions, keywords)
+			exitOnError(err)
+			recordResults(len(podResults))
+			switch len(podResults) {
+			case 0:
+				exitOnError(fmt.Errorf("no pod matches %q", strings.Join(keywords, " ")))
+			case 1:
+			default:
+				var lines []string
+				for i := range podResults {
+					lines = append(lines, podResults[i].StatusLine)
+				}
+				fmt.Fprintf(os.Stderr, "Error: %d pods match, narrow the search down to one of:\n", len(podResults))
+				util.PrintTable(util.PodHeader, lines)
+				exit(exitError)
+			}
+
+			pod := podResults[0].Pod
+			container := containerName
+			if container == "" {
+				container = pod.Spec.Containers[0].Name
+			}
+
+			// only ask for a tty when there is one to attach
+			kubectlArgs := []string{"exec"}
+			if isatty.IsTerminal(os.Stdin.Fd()) {
+				kubectlArgs = append(kubectlArgs, "-it")
+			} else {
+				kubectlArgs = append(kubectlArgs, "-i")
+			}
+			kubectlArgs = append(kubectlArgs, pod.Name, "--container="+container)
+
+			exitCode, err := util.ClientFor(searchOptions).RawK8sInteractive(pod.Namespace, searchOptions.Context, searchOptions.Kubeconfig, kubectlArgs, command...)
+			exitOnError(err)
+			exit(exitCode)
+		},
+	}
+)
+
+func init() {
+	execCmd.Flags().StringVarP(&containerName, "container", "c", "",
+		"Container to run the command in. (default: the pod's first container)")
+	rootCmd.AddCommand(execCmd)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// serverPrint - render rows with the columns the API server prints
+	serverPrint bool
+
+	getCmd = &cobra.Command{
+		Use:   "get <resource> [keyword...]",
+		Short: "Search any resource by name, including custom resources",
+		Long: `lists objects of any resource the cluster serves, resolved like kubectl does,
+e.g. kk get certificates.cert-manager.io api`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args[1:])
+
+			runOrWatch(args[0], func(opt *options.SearchOptions) (table, error) {
+				// every context searched resolves the resource on its own
+				gvr, namespaced, err := util.ClientFor(opt).ResolveResource(args[0])
+				if err != nil {
+					return table{}, err
+				}
+				header := util.ResourceHeader
+				if !namespaced {
+					header = util.ClusterResourceHeader
+				}
+
+				var results []resources.GetResourcesResponse
+				if serverPrint {
+					header, results, err = resources.GetServerTable(opt, keywords, gvr, namespaced, outputOptions.IsWide())
+				} else {
+					results, err = resources.GetResources(opt, keywords, gvr, namespaced)
+				}
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range results {
+					lines = append(lines, results[i].StatusLine)
+					objects = append(objects, &results[i].Object)
+				}
+				return table{header: header, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	crdCmd = &cobra.Command{
+		Use:     "crd",
+		Aliases: []string{"crds", "customresourcedefinition", "customresourcedefinitions"},
+		Short:   "Search custom resource definitions by name, group or kind",
+		Long:    `lists custom resource definitions with their group, kind, scope and served versions`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("customresourcedefinitions", func(opt *options.SearchOptions) (table, error) {
+				crdResults, err := resources.GetCustomResourceDefinitions(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range crdResults {
+					lines = append(lines, crdResults[i].StatusLine)
+					objects = append(objects, &crdResults[i].Object)
+				}
+				return table{header: util.CrdHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	getCmd.Flags().BoolVar(
+		&serverPrint, "server-print", false,
+		"If present, print the columns the API server renders for the resource, those kubectl shows; -o wide adds the lower priority ones.")
+	rootCmd.AddCommand(getCmd)
+	rootCmd.AddCommand(crdCmd)
+}
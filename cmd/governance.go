@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pdbCmd = &cobra.Command{
+		Use:     "pdb",
+		Aliases: []string{"pdbs", "poddisruptionbudget", "poddisruptionbudgets"},
+		Short:   "Search pod disruption budgets by name",
+		Long:    `lists pod disruption budgets with their min available / max unavailable, allowed disruptions and healthy pods`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("poddisruptionbudgets", func(opt *options.SearchOptions) (table, error) {
+				pdbResults, err := resources.GetPodDisruptionBudgets(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range pdbResults {
+					lines = append(lines, pdbResults[i].StatusLine)
+					objects = append(objects, &pdbResults[i].PodDisruptionBudget)
+				}
+				return table{header: util.PdbHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	quotaCmd = &cobra.Command{
+		Use:     "quota",
+		Aliases: []string{"quotas", "resourcequota", "resourcequotas"},
+		Short:   "Search resource quotas by name",
+		Long:    `lists resource quotas with used vs hard for every resource they limit`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("resourcequotas", func(opt *options.SearchOptions) (table, error) {
+				quotaResults, err := resources.GetResourceQuotas(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range quotaResults {
+					lines = append(lines, quotaResults[i].StatusLine)
+					objects = append(objects, &quotaResults[i].ResourceQuota)
+				}
+				return table{header: util.QuotaHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	limitRangeCmd = &cobra.Command{
+		Use:     "limitrange",
+		Aliases: []string{"limitranges", "limits"},
+		Short:   "Search limit ranges by name",
+		Long:    `lists limit ranges with the default requests and limits, min and max they set for each type`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("limitranges", func(opt *options.SearchOptions) (table, error) {
+				limitRangeResults, err := resources.GetLimitRanges(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range limitRangeResults {
+					lines = append(lines, limitRangeResults[i].StatusLine)
+					objects = append(objects, &limitRangeResults[i].LimitRange)
+				}
+				return table{header: util.LimitRangeHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(pdbCmd)
+	rootCmd.AddCommand(quotaCmd)
+	rootCmd.AddCommand(limitRangeCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	hpaCmd = &cobra.Command{
+		Use:     "hpa",
+		Aliases: []string{"hpas", "horizontalpodautoscaler", "horizontalpodautoscalers"},
+		Short:   "Search horizontal pod autoscalers by name or scale target",
+		Long:    `lists horizontal pod autoscalers with their scale target, replica bounds and current vs target metrics`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("horizontalpodautoscalers", func(opt *options.SearchOptions) (table, error) {
+				hpaResults, err := resources.GetHPAs(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range hpaResults {
+					lines = append(lines, hpaResults[i].StatusLine)
+					objects = append(objects, &hpaResults[i].HPA)
+				}
+				return table{header: util.HpaHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(hpaCmd)
+}
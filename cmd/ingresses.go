@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ingressCmd = &cobra.Command{
+		Use:     "ingress",
+		Aliases: []string{"ingresses", "ing"},
+		Short:   "Search ingresses by name, host or backend service",
+		Long:    `lists ingresses whose name, host names or backend service names contain the keyword`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("ingresses", func(opt *options.SearchOptions) (table, error) {
+				ingressResults, err := resources.GetIngresses(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range ingressResults {
+					lines = append(lines, ingressResults[i].StatusLine)
+					objects = append(objects, &ingressResults[i].Ingress)
+				}
+				return table{header: util.IngressHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(ingressCmd)
+}
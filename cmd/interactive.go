@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"github.com/mattn/go-isatty"
+)
+
+// interactive - pick one of the matches and act on it instead of printing them
+var interactive bool
+
+// podActions - what can be done with the picked pod, and the kubectl command
+// each of them runs
+var podActions = []struct {
+	Name string
+	Args func(name string, container string) ([]string, []string)
+}{
+	{"describe", func(name, _ string) ([]string, []string) {
+		return []string{"describe", "pod", name}, nil
+	}},
+	{"logs", func(name, _ string) ([]string, []string) {
+		return []string{"logs", name, "--all-containers=true"}, nil
+	}},
+	{"exec", func(name, container string) ([]string, []string) {
+		return []string{"exec", "-it", name, "--container=" + container}, defaultExecCommand
+	}},
+	{"yaml", func(name, _ string) ([]string, []string) {
+		return []string{"get", "pod", name, "-oyaml"}, nil
+	}},
+}
+
+// isInteractive - report whether the picker can be shown: -i was given, a
+// terminal is there to draw it on and nothing else asked for the output
+func isInteractive() bool {
+	if !interactive || quiet || watchResults || isMultiContext() {
+		return false
+	}
+	if outputOptions.IsMachine() || outputOptions.IsAggregate() {
+		return false
+	}
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// pickPod - filter the matched pods as you type, then describe, print the
+// logs of, exec into or print the picked one. It exits with kubectl's exit
+// code.
+func pickPod(podResults []resources.GetPodsResponse) {
+	prompt := promptui.Select{
+		Label: "POD NAME",
+		Items: podResults,
+		Templates: &promptui.SelectTemplates{
+			Active:   "{{ .Pod.Namespace }}/{{ .Pod.Name | underline | yellow }}",
+			Inactive: "{{ .Pod.Namespace }}/{{ .Pod.Name }}",
+			Details: `
+` + util.PodHeader + `
+{{ .StatusLine }}`,
+		},
+		Size: 20,
+		Searcher: func(input string, index int) bool {
+			pod := podResults[index].Pod
+			return strings.Contains(strings.ToLower(pod.Namespace+"/"+pod.Name), strings.ToLower(strings.TrimSpace(input)))
+		},
+		StartInSearchMode: true,
+	}
+	i, _, err := prompt.Run()
+	if err != nil {
+		return
+	}
+	pod := podResults[i].Pod
+
+	var names []string
+	for _, action := range podActions {
+		names = append(names, action.Name)
+	}
+	actionPrompt := promptui.Select{
+		Label: pod.Name,
+		Items: names,
+	}
+	a, _, err := actionPrompt.Run()
+	if err != nil {
+		return
+	}
+
+	args, command := podActions[a].Args(pod.Name, pod.Spec.Containers[0].Name)
+	exitCode, err := util.ClientFor(searchOptions).RawK8sInteractive(pod.Namespace, searchOptions.Context, searchOptions.Kubeconfig, args, command...)
+	exitOnError(err)
+	exit(exitCode)
+}
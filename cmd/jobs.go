@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	jobCmd = &cobra.Command{
+		Use:     "job",
+		Aliases: []string{"jobs"},
+		Short:   "Search jobs by name",
+		Long:    `lists jobs with their completion counts and whether they succeeded or failed`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("jobs", func(opt *options.SearchOptions) (table, error) {
+				jobResults, err := resources.GetJobs(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range jobResults {
+					lines = append(lines, jobResults[i].StatusLine)
+					objects = append(objects, &jobResults[i].Job)
+				}
+				return table{header: util.JobHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	cronJobCmd = &cobra.Command{
+		Use:     "cronjob",
+		Aliases: []string{"cronjobs", "cj"},
+		Short:   "Search cronjobs by name",
+		Long:    `lists cronjobs with their schedule and when they were last scheduled`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("cronjobs", func(opt *options.SearchOptions) (table, error) {
+				cronJobResults, err := resources.GetCronJobs(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range cronJobResults {
+					lines = append(lines, cronJobResults[i].StatusLine)
+					objects = append(objects, &cronJobResults[i].CronJob)
+				}
+				return table{header: util.CronJobHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(jobCmd)
+	rootCmd.AddCommand(cronJobCmd)
+}
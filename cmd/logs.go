@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	containerName string
+	followLogs    bool
+	previousLogs  bool
+	tailLines     int64
+
+	logsCmd = &cobra.Command{
+		Use:     "logs",
+		Aliases: []string{"log"},
+		Short:   "Print the logs of the pods matching a search",
+		Long: `prints the logs of every container of the matching pods; when more
+than one container is printed, each line is prefixed with its pod/container`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			podResults, err := resources.GetPods(searchOptions, keywords)
+			exitOnError(err)
+			recordResults(len(podResults))
+			if len(podResults) == 0 {
+				fmt.Println("No resources found.")
+				return
+			}
+
+			var streams []logStream
+			for i := range podResults {
+				pod := &podResults[i].Pod
+				for _, c := range pod.Spec.Containers {
+					if containerName == "" || c.Name == containerName {
+						streams = append(streams, logStream{pod: pod, container: c.Name})
+					}
+				}
+			}
+			if len(streams) == 0 {
+				exitOnError(fmt.Errorf("none of the %d matching pod(s) has a container named %q", len(podResults), containerName))
+			}
+			exitOnError(printLogs(streams))
+		},
+	}
+)
+
+// logStream - one container whose logs are printed
+type logStream struct {
+	pod       *corev1.Pod
+	container string
+}
+
+// printLogs - print the logs of every stream: one after the other, or all at
+// once with --follow, where they never end. A stream that fails doesn't stop
+// the others; its error is printed and returned once they're done.
+func printLogs(streams []logStream) error {
+	var colors util.ColorManager
+	prefixes := make([]string, len(streams))
+	if len(streams) > 1 {
+		for i, s := range streams {
+			prefixes[i] = colors.Colorize(s.pod.Name+"/"+s.container) + " "
+		}
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed error
+	)
+	run := func(i int) {
+		if err := printLog(streams[i], prefixes[i], &mu); err != nil {
+			mu.Lock()
+			fmt.Fprintf(os.Stderr, "Error: %s/%s: %v\n", streams[i].pod.Name, streams[i].container, err)
+			failed = fmt.Errorf("printing the logs of %d container(s) failed", len(streams))
+			mu.Unlock()
+		}
+	}
+	for i := range streams {
+		if !followLogs {
+			run(i)
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			run(i)
+		}(i)
+	}
+	wg.Wait()
+	return failed
+}
+
+// printLog - copy the logs of s to stdout line by line, each line starting
+// with prefix. mu keeps lines of concurrent streams from interleaving.
+func printLog(s logStream, prefix string, mu *sync.Mutex) error {
+	opt := &corev1.PodLogOptions{
+		Container: s.container,
+		Follow:    followLogs,
+		Previous:  previousLogs,
+	}
+	if tailLines >= 0 {
+		opt.TailLines = &tailLines
+	}
+	stream, err := util.ClientFor(searchOptions).PodLogs(s.pod, opt)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if line[len(line)-1] != '\n' {
+				line += "\n"
+			}
+			mu.Lock()
+			fmt.Print(prefix + line)
+			mu.Unlock()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	logsCmd.Flags().StringVarP(&containerName, "container", "c", "",
+		"Only print the logs of the container with this name. (default: every container)")
+	logsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false,
+		"If present, keep streaming new log lines of every container until interrupted.")
+	logsCmd.Flags().Int64Var(&tailLines, "tail", -1,
+		"Number of most recent lines to print from each container, -1 for all of them.")
+	logsCmd.Flags().BoolVarP(&previousLogs, "previous", "p", false,
+		"If present, print the logs of the previous, terminated instance of each container.")
+	rootCmd.AddCommand(logsCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	namespaceCmd = &cobra.Command{
+		Use:     "namespace",
+		Aliases: []string{"namespaces", "ns"},
+		Short:   "Search namespaces by name",
+		Long:    `lists namespaces with their phase (Active/Terminating) and age`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("namespaces", func(opt *options.SearchOptions) (table, error) {
+				namespaceResults, err := resources.GetNamespaces(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range namespaceResults {
+					lines = append(lines, namespaceResults[i].StatusLine)
+					objects = append(objects, &namespaceResults[i].Namespace)
+				}
+				return table{header: util.NamespaceHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(namespaceCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkPolicyCmd = &cobra.Command{
+		Use:     "networkpolicy",
+		Aliases: []string{"networkpolicies", "netpol"},
+		Short:   "Search network policies by name or pod selector",
+		Long:    `lists network policies with the pods they select and their number of ingress and egress rules`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("networkpolicies", func(opt *options.SearchOptions) (table, error) {
+				policyResults, err := resources.GetNetworkPolicies(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range policyResults {
+					lines = append(lines, policyResults[i].StatusLine)
+					objects = append(objects, &policyResults[i].NetworkPolicy)
+				}
+				return table{header: util.NetworkPolicyHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(networkPolicyCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	showNodePods bool
+
+	nodeCmd = &cobra.Command{
+		Use:     "node",
+		Aliases: []string{"nodes", "no"},
+		Short:   "Search nodes by name",
+		Long: `lists nodes with their status, roles and kubelet version;
+--pods lists the pods scheduled on each matching node instead`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("nodes", func(opt *options.SearchOptions) (table, error) {
+				nodeResults, err := resources.GetNodes(opt, keywords, showNodePods)
+				if err != nil {
+					return table{}, err
+				}
+
+				var objects []runtime.Object
+				for i := range nodeResults {
+					objects = append(objects, &nodeResults[i].Node)
+				}
+				if !showNodePods {
+					header := util.NodeHeader
+					if outputOptions.IsWide() {
+						header = util.NodeHeaderWide
+					}
+					var lines []string
+					for i := range nodeResults {
+						line := nodeResults[i].StatusLine
+						if outputOptions.IsWide() {
+							line = nodeResults[i].Match.Highlight(resources.NewNodeDetailsWide(nodeResults[i].Node))
+						}
+						lines = append(lines, line)
+					}
+					return table{header: header, lines: lines, objects: objects}, nil
+				}
+
+				var lines []string
+				var rowObjects []runtime.Object
+				for i := range nodeResults {
+					for _, pod := range nodeResults[i].Pods {
+						lines = append(lines, nodeResults[i].Match.Highlight(nodeResults[i].Node.Name)+"\t"+resources.NewPodRow(pod))
+						rowObjects = append(rowObjects, &nodeResults[i].Node)
+					}
+				}
+				return table{header: util.NodePodHeader, lines: lines, rowObjects: rowObjects, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	nodeCmd.Flags().StringVar(&searchOptions.Taint, "taint", "",
+		"Only show nodes with this taint, given as key[=value][:effect], e.g. --taint=nvidia.com/gpu or --taint=dedicated=db:NoSchedule.")
+	nodeCmd.Flags().BoolVar(&showNodePods, "pods", false,
+		"If present, list the pods scheduled on each matching node.")
+	rootCmd.AddCommand(nodeCmd)
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	// outputFile - where --output-file sends the output, stdout when empty
+	outputFile string
+	// printKubectl - print the kubectl get command of every match instead
+	printKubectl bool
+	// quiet - print nothing to stdout, only set the exit code
+	quiet bool
+)
+
+// table - what a search found: a table row per line, rendered from the
+// object at the same index of rowObjects, or of objects when rowObjects is
+// nil. With groups the rows are printed under the group of each, rows
+// without one last under untitled.
+type table struct {
+	header     string
+	lines      []string
+	rowObjects []runtime.Object
+	objects    []runtime.Object
+
+	groups   []string
+	untitled string
+}
+
+// searchFunc - run a search with opt, e.g. on one of several contexts
+type searchFunc func(opt *options.SearchOptions) (table, error)
+
+// printTable - print what a search found in the format chosen with --output
+func printTable(t table) {
+	if t.groups != nil {
+		printGroupedResults(t.header, t.lines, t.objects, t.groups, t.untitled)
+		return
+	}
+	rowObjects := t.rowObjects
+	if rowObjects == nil {
+		rowObjects = t.objects
+	}
+	printResultRows(t.header, t.lines, rowObjects, t.objects)
+}
+
+// printResults - print the matched objects in the format chosen with --output.
+// lines holds the table row of each object, in the same order as objects.
+func printResults(header string, lines []string, objects []runtime.Object) {
+	printResultRows(header, lines, objects, objects)
+}
+
+// printResultRows - like printResults, for tables with more or fewer rows than
+// objects. rowObjects holds the object each line was rendered from.
+func printResultRows(header string, lines []string, rowObjects []runtime.Object, objects []runtime.Object) {
+	recordResults(len(objects))
+	if outputOptions.Count {
+		fmt.Println(len(objects))
+		return
+	}
+	if len(outputOptions.Summary) > 0 {
+		printSummary(outputOptions.Summary, objects)
+		return
+	}
+	if spec, ok := outputOptions.CustomColumns(); ok {
+		columns, err := util.ParseCustomColumns(spec)
+		exitOnError(err)
+		exitOnError(util.PrintCustomColumns(os.Stdout, columns, objects))
+		return
+	}
+	if text, fromFile, ok := outputOptions.GoTemplate(); ok {
+		tmpl, err := util.ParseTemplate(text, fromFile)
+		exitOnError(err)
+		exitOnError(util.PrintTemplate(os.Stdout, tmpl, objects))
+		return
+	}
+	if printKubectl {
+		exitOnError(util.ClientFor(searchOptions).PrintKubectlCommands(os.Stdout, objects, searchOptions.Context, searchOptions.Selector, searchOptions.Kubeconfig, searchOptions.Timeout))
+		return
+	}
+	if outputOptions.IsName() {
+		exitOnError(util.PrintNames(os.Stdout, objects, searchOptions.AllNamespaces))
+		return
+	}
+	if outputOptions.IsMachine() && !outputOptions.IsDelimited() {
+		exitOnError(util.PrintObjects(os.Stdout, outputOptions.Format, objects))
+		return
+	}
+
+	header, lines = extendRows(header, lines, rowObjects)
+	if outputOptions.IsDelimited() {
+		comma := ','
+		if outputOptions.Format == "tsv" {
+			comma = '\t'
+		}
+		exitOnError(util.PrintDelimited(os.Stdout, comma, header, lines))
+		return
+	}
+	if len(lines) == 0 {
+		fmt.Println("No resources found.")
+		return
+	}
+	util.PrintTable(header, lines)
+}
+
+// extendRows - add the columns asked for with --deep, --label-columns and
+// --columns to the table. rowObjects holds the object of each line.
+func extendRows(header string, lines []string, rowObjects []runtime.Object) (string, []string) {
+	if searchOptions.Deep && len(searchedKeywords) > 0 {
+		header += "\t" + util.MatchedLineColumn
+		matched := make([]string, len(lines))
+		for i, line := range lines {
+			matchedLine := resources.DeepMatchLine(searchOptions, searchedKeywords, rowObjects[i])
+			if matchedLine == "" {
+				matchedLine = "<none>"
+			}
+			matched[i] = line + "\t" + matchedLine
+		}
+		lines = matched
+	}
+	if keys := outputOptions.LabelColumns; len(keys) > 0 {
+		header += "\t" + util.LabelColumnHeader(keys)
+		labelled := make([]string, len(lines))
+		for i, line := range lines {
+			labelled[i] = line + "\t" + util.LabelColumnValues(keys, rowObjects[i])
+		}
+		lines = labelled
+	}
+	if spec := outputOptions.Columns; spec != "" {
+		columns, err := util.ParseFieldColumns(spec)
+		exitOnError(err)
+		header += "\t" + util.FieldColumnHeader(columns)
+		extended := make([]string, len(lines))
+		for i, line := range lines {
+			values, err := util.FieldColumnValues(columns, rowObjects[i])
+			exitOnError(err)
+			extended[i] = line + "\t" + values
+		}
+		lines = extended
+	}
+	return header, lines
+}
+
+// printGroupedResults - print the table of the matched objects with its rows
+// under the group each belongs to, given by groups, e.g. their owner. Rows
+// without a group come last under untitled.
+func printGroupedResults(header string, lines []string, objects []runtime.Object, groups []string, untitled string) {
+	recordResults(len(objects))
+	header, lines = extendRows(header, lines, objects)
+	if len(lines) == 0 {
+		fmt.Println("No resources found.")
+		return
+	}
+	util.PrintGroupedTable(header, lines, groups, untitled)
+}
+
+// validateOutput - fail on a bad --output value before anything is queried
+func validateOutput() error {
+	if err := outputOptions.Validate(); err != nil {
+		return err
+	}
+	if outputOptions.Count && len(outputOptions.Summary) > 0 {
+		return fmt.Errorf("--count and --summary can't be used together")
+	}
+	if outputOptions.IsAggregate() && outputOptions.IsMachine() {
+		return fmt.Errorf("--count and --summary can't be combined with -o %s", outputOptions.Format)
+	}
+	if printKubectl && (outputOptions.Format != "" || outputOptions.IsAggregate()) {
+		return fmt.Errorf("--print-kubectl prints a command per match and can't be combined with -o, --count or --summary")
+	}
+	if quiet && (outputFile != "" || watchResults) {
+		return fmt.Errorf("--quiet prints nothing and can't be combined with --output-file or --watch")
+	}
+	if err := validateSummary(); err != nil {
+		return err
+	}
+	if spec, ok := outputOptions.CustomColumns(); ok {
+		_, err := util.ParseCustomColumns(spec)
+		return err
+	}
+	if text, fromFile, ok := outputOptions.GoTemplate(); ok {
+		_, err := util.ParseTemplate(text, fromFile)
+		return err
+	}
+	if spec := outputOptions.Columns; spec != "" {
+		_, err := util.ParseFieldColumns(spec)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ownerCmd = &cobra.Command{
+		Use:     "owner",
+		Aliases: []string{"owners"},
+		Short:   "Search pods by name and show the controllers owning them",
+		Long: `lists pods with their chain of owners, e.g. ReplicaSet -> Deployment,
+and the root controller; owners that were deleted are marked (deleted)`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("pods", func(opt *options.SearchOptions) (table, error) {
+				ownerResults, err := resources.GetOwners(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range ownerResults {
+					lines = append(lines, ownerResults[i].StatusLine)
+					objects = append(objects, &ownerResults[i].Pod)
+				}
+				return table{header: util.OwnerHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(ownerCmd)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// noPager - print straight to the terminal, for --no-pager
+var noPager bool
+
+// unpagedCommands - commands that draw a prompt, stream or attach to the
+// terminal, which a pager would get in the way of
+var unpagedCommands = []string{"exec", "logs", "service", "completion", "__complete", "cache", "clear"}
+
+// usePager - report whether the output of cmd goes through the pager: only
+// when stdout is a terminal and the output is printed once
+func usePager(cmd *cobra.Command) bool {
+	if noPager || quiet || watchResults || isInteractive() || contains(unpagedCommands, cmd.Name()) {
+		return false
+	}
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(
+		&noPager, "no-pager", false,
+		"If present, never page the output. (default: pages through $PAGER, or less -FRX, when the output is a terminal and longer than a screen)")
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	onNode     string
+	podsOf     string
+	showImages bool
+	groupBy    string
+
+	podCmd = &cobra.Command{
+		Use:     "pod",
+		Aliases: []string{"pods", "po"},
+		Short:   "Search pods by name",
+		Long:    `lists pods with their readiness, status and restart counts`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+			if onNode != "" {
+				// let the API server do the filtering, it indexes pods by node
+				selector := "spec.nodeName=" + onNode
+				if searchOptions.FieldSelector != "" {
+					selector = searchOptions.FieldSelector + "," + selector
+				}
+				searchOptions.FieldSelector = selector
+			}
+
+			exitOnError(validateGroupBy())
+
+			if isInteractive() {
+				opt, err := podsOfOptions(searchOptions)
+				exitOnError(err)
+				podResults, err := resources.GetPods(opt, keywords)
+				exitOnError(err)
+				recordResults(len(podResults))
+				if len(podResults) == 0 {
+					printResults(util.PodHeader, nil, nil)
+					return
+				}
+				pickPod(podResults)
+				return
+			}
+
+			runOrWatch("pods", func(opt *options.SearchOptions) (table, error) {
+				opt, err := podsOfOptions(opt)
+				if err != nil {
+					return table{}, err
+				}
+				podResults, err := resources.GetPods(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				header := util.PodHeader
+				if outputOptions.IsWide() {
+					header = util.PodHeaderWide
+				}
+				if showImages {
+					header += "\t" + util.ImagesColumn
+				}
+				for i := range podResults {
+					line := podResults[i].StatusLine
+					if outputOptions.IsWide() {
+						line = podResults[i].Match.Highlight(resources.NewPodRowWide(podResults[i].Pod))
+					}
+					if showImages {
+						line += "\t" + resources.ContainerImages(podResults[i].Pod.Spec)
+					}
+					lines = append(lines, line)
+					objects = append(objects, &podResults[i].Pod)
+				}
+				if groupBy != "" {
+					return podsByOwner(opt, header, lines, podResults)
+				}
+				return table{header: header, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	podCmd.Flags().StringVar(&onNode, "on-node", "",
+		"Only show pods scheduled on this node.")
+	podCmd.Flags().StringVar(&podsOf, "pods-of", "",
+		"Only show the pods selected by this deployment, its spec.selector matchLabels and matchExpressions, e.g. --pods-of=web. Combined with --selector, pods have to match both.")
+	podCmd.Flags().StringVar(&groupBy, "group-by", "",
+		"Print the pods under the controller owning them, e.g. their Deployment, StatefulSet or DaemonSet, pods without one last. One of: owner")
+	podCmd.Flags().BoolVar(&showImages, "show-images", false,
+		"If present, add a column with the image of every container in the pod.")
+	podCmd.Flags().StringVar(&searchOptions.Image, "image", "",
+		"Only show pods with a container whose image contains this text, e.g. --image=nginx:1.19.")
+	podCmd.Flags().StringVar(&searchOptions.Status, "status", "",
+		"Only show pods with this status as shown in the STATUS column, e.g. --status=CrashLoopBackOff. Init:<reason> also matches <reason>.")
+	podCmd.Flags().StringVar(&searchOptions.NodeSelector, "node-selector", "",
+		"Only show pods whose spec.nodeSelector matches this label selector, e.g. --node-selector=gpu=true.")
+	podCmd.Flags().StringVar(&searchOptions.Toleration, "toleration", "",
+		"Only show pods tolerating this taint, given as key[=value][:effect], e.g. --toleration=nvidia.com/gpu:NoSchedule.")
+	podCmd.Flags().StringVar(&searchOptions.Since, "since", "",
+		"Only show pods with a container that terminated within this long, e.g. --since=10m to find what crashed during an incident.")
+	podCmd.Flags().Int32Var(&searchOptions.MinRestarts, "min-restarts", 0,
+		"Only show pods whose containers restarted at least this many times in total, most restarts first unless --sort-by is given, e.g. --min-restarts=5.")
+	podCmd.Flags().BoolVar(&searchOptions.NotReady, "not-ready", false,
+		"If present, only show pods where not all containers are ready.")
+	podCmd.Flags().BoolVarP(&interactive, "interactive", "i", false,
+		"If present, filter the matched pods as you type and pick one to describe, print the logs of, exec into or print as YAML. Ignored when kk isn't run in a terminal.")
+	rootCmd.AddCommand(podCmd)
+}
+
+// podsOfOptions - opt narrowed down to the namespace and selector of the
+// deployment given with --pods-of, opt itself without it
+func podsOfOptions(opt *options.SearchOptions) (*options.SearchOptions, error) {
+	if podsOf == "" {
+		return opt, nil
+	}
+	namespace, selector, err := util.ClientFor(opt).DeploymentSelector(opt, podsOf)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Selector != "" {
+		selector += "," + opt.Selector
+	}
+	o := *opt
+	o.Namespaces, o.AllNamespaces = []string{namespace}, false
+	o.Selector = selector
+	return &o, nil
+}
+
+// podsByOwner - the pod table with the rows grouped under the root
+// controller of each pod, for --group-by=owner
+func podsByOwner(opt *options.SearchOptions, header string, lines []string, pods []resources.GetPodsResponse) (table, error) {
+	metaObjects := make([]metav1.Object, len(pods))
+	objects := make([]runtime.Object, len(pods))
+	for i := range pods {
+		metaObjects[i], objects[i] = &pods[i].Pod, &pods[i].Pod
+	}
+	owners, err := resources.RootOwners(opt, metaObjects)
+	if err != nil {
+		return table{}, err
+	}
+
+	groups := make([]string, len(pods))
+	for i, owner := range owners {
+		if owner == nil {
+			continue
+		}
+		groups[i] = fmt.Sprintf("%s %s/%s", owner.Kind, pods[i].Pod.Namespace, owner.Name)
+		if owner.Missing {
+			groups[i] += " (deleted)"
+		}
+	}
+	return table{header: header, lines: lines, objects: objects, groups: groups, untitled: "no owner"}, nil
+}
+
+// validateGroupBy - fail on an unknown --group-by, or an output the groups
+// can't be shown in
+func validateGroupBy() error {
+	switch {
+	case groupBy == "":
+		return nil
+	case groupBy != "owner":
+		return fmt.Errorf("unknown --group-by %q, expected owner", groupBy)
+	case outputOptions.IsMachine() || outputOptions.IsAggregate() || printKubectl || isMultiContext():
+		return fmt.Errorf("--group-by prints a table and can't be combined with -o json|yaml|name|csv|tsv, --count, --summary, --print-kubectl or --contexts")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	roleCmd = &cobra.Command{
+		Use:     "role",
+		Aliases: []string{"roles"},
+		Short:   "Search roles by name",
+		Long:    `lists roles with the number of rules they grant`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("roles", func(opt *options.SearchOptions) (table, error) {
+				roleResults, err := resources.GetRoles(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range roleResults {
+					lines = append(lines, roleResults[i].StatusLine)
+					objects = append(objects, &roleResults[i].Role)
+				}
+				return table{header: util.RoleHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	roleBindingCmd = &cobra.Command{
+		Use:     "rolebinding",
+		Aliases: []string{"rolebindings", "rb"},
+		Short:   "Search role bindings by name, role or subject",
+		Long:    `lists role bindings with the role they grant and the users, groups and service accounts they grant it to`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("rolebindings", func(opt *options.SearchOptions) (table, error) {
+				roleBindingResults, err := resources.GetRoleBindings(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range roleBindingResults {
+					lines = append(lines, roleBindingResults[i].StatusLine)
+					objects = append(objects, &roleBindingResults[i].RoleBinding)
+				}
+				return table{header: util.RoleBindingHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	clusterRoleCmd = &cobra.Command{
+		Use:     "clusterrole",
+		Aliases: []string{"clusterroles", "cr"},
+		Short:   "Search cluster roles by name",
+		Long:    `lists cluster roles with the number of rules they grant`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("clusterroles", func(opt *options.SearchOptions) (table, error) {
+				clusterRoleResults, err := resources.GetClusterRoles(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range clusterRoleResults {
+					lines = append(lines, clusterRoleResults[i].StatusLine)
+					objects = append(objects, &clusterRoleResults[i].ClusterRole)
+				}
+				return table{header: util.ClusterRoleHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	clusterRoleBindingCmd = &cobra.Command{
+		Use:     "clusterrolebinding",
+		Aliases: []string{"clusterrolebindings", "crb"},
+		Short:   "Search cluster role bindings by name, role or subject",
+		Long:    `lists cluster role bindings with the role they grant and the users, groups and service accounts they grant it to`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("clusterrolebindings", func(opt *options.SearchOptions) (table, error) {
+				clusterBindingResults, err := resources.GetClusterRoleBindings(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range clusterBindingResults {
+					lines = append(lines, clusterBindingResults[i].StatusLine)
+					objects = append(objects, &clusterBindingResults[i].ClusterRoleBinding)
+				}
+				return table{header: util.ClusterBindingHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(roleCmd)
+	rootCmd.AddCommand(roleBindingCmd)
+	rootCmd.AddCommand(clusterRoleCmd)
+	rootCmd.AddCommand(clusterRoleBindingCmd)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	replicaSetCmd = &cobra.Command{
+		Use:     "replicaset",
+		Aliases: []string{"replicasets", "rs"},
+		Short:   "Search replicasets by name or owning deployment",
+		Long:    `lists replicasets with their owning deployment and desired/current/ready replicas`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("replicasets", func(opt *options.SearchOptions) (table, error) {
+				replicaSetResults, err := resources.GetReplicaSets(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				header := util.ReplicaSetHeader
+				if outputOptions.IsWide() {
+					header = util.ReplicaSetHeaderWide
+				}
+				for i := range replicaSetResults {
+					result := replicaSetResults[i]
+					line := result.StatusLine
+					if outputOptions.IsWide() {
+						line = result.Match.Highlight(resources.NewReplicaSetDetailsWide(result.ReplicaSet, result.Owner))
+					}
+					lines = append(lines, line)
+					objects = append(objects, &replicaSetResults[i].ReplicaSet)
+				}
+				return table{header: header, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(replicaSetCmd)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	replicationControllerCmd = &cobra.Command{
+		Use:     "replicationcontroller",
+		Aliases: []string{"replicationcontrollers", "rc"},
+		Short:   "Search replication controllers by name",
+		Long:    `lists the legacy replication controllers with their desired/current/ready replicas`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("replicationcontrollers", func(opt *options.SearchOptions) (table, error) {
+				rcResults, err := resources.GetReplicationControllers(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				header := util.RcHeader
+				if outputOptions.IsWide() {
+					header = util.RcHeaderWide
+				}
+				for i := range rcResults {
+					result := rcResults[i]
+					line := result.StatusLine
+					if outputOptions.IsWide() {
+						line = result.Match.Highlight(resources.NewReplicationControllerDetailsWide(result.ReplicationController))
+					}
+					lines = append(lines, line)
+					objects = append(objects, &rcResults[i].ReplicationController)
+				}
+				return table{header: header, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(replicationControllerCmd)
+}
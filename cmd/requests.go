@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	byNamespace bool
+
+	requestsCmd = &cobra.Command{
+		Use:   "requests [keyword...]",
+		Short: "Search pods by name and show the CPU and memory they request",
+		Long: `lists pods with their CPU and memory requests and limits, or with
+--by-namespace the totals of each namespace, e.g. kk requests -A --by-namespace`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+			if byNamespace && (outputOptions.IsAggregate() || (outputOptions.IsMachine() && !outputOptions.IsDelimited())) {
+				exitOnError(fmt.Errorf("--by-namespace prints a table of totals and can't be combined with -o json|yaml|name, --count or --summary"))
+			}
+
+			runOrWatch("pods", func(opt *options.SearchOptions) (table, error) {
+				results, err := resources.GetPodRequests(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var objects []runtime.Object
+				for i := range results {
+					objects = append(objects, &results[i].Pod)
+				}
+				if !byNamespace {
+					var lines []string
+					for i := range results {
+						lines = append(lines, results[i].StatusLine)
+					}
+					return table{header: util.RequestsHeader, lines: lines, objects: objects}, nil
+				}
+
+				var lines []string
+				var rowObjects []runtime.Object
+				for _, total := range resources.NamespaceTotals(results) {
+					lines = append(lines, resources.NewNamespaceTotalDetails(total))
+					rowObjects = append(rowObjects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: total.Namespace}})
+				}
+				return table{header: util.NamespaceTotalsHeader, lines: lines, rowObjects: rowObjects, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	requestsCmd.Flags().BoolVar(
+		&byNamespace, "by-namespace", false,
+		"If present, print the requests and limits of the matching pods summed per namespace instead of one row per pod.")
+	rootCmd.AddCommand(requestsCmd)
+}
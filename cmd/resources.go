@@ -2,41 +2,71 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/manifoldco/promptui"
+	"github.com/mateo1647/kk/internal/options"
 	"github.com/mateo1647/kk/resources"
 	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/spf13/cobra"
 )
 
 var (
+	showEndpoints bool
+
 	serviceCmd = &cobra.Command{
 		Use:     "service",
 		Aliases: []string{"services", "svc"},
 		Short:   "Service list with pod details",
 		Long:    `shows pod details along with service details`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if namespace == "" {
-				namespace = util.GetDefaultNamespace()
-			}
+			keywords := searchKeywords(args)
+
+			exitOnError(util.ValidateFieldSelector("services", searchOptions.FieldSelector))
+			exitOnError(validateServiceType(searchOptions.ServiceType))
 
-			var keyword string
+			// machine readable output, counts, --contexts and --quiet replace the interactive picker
+			if outputOptions.IsMachine() || outputOptions.IsAggregate() || isMultiContext() || quiet {
+				runSearch(func(opt *options.SearchOptions) (table, error) {
+					serviceResults, err := resources.GetServicesandPods(opt, keywords, showEndpoints)
+					if err != nil {
+						return table{}, err
+					}
 
-			if len(args) >= 1 && args[0] != "" {
-				keyword = util.TrimQuoteAndSpace(args[0])
+					var lines []string
+					var objects []runtime.Object
+					for i := range serviceResults {
+						lines = append(lines, serviceResults[i].StatusLine)
+						objects = append(objects, &serviceResults[i].Service)
+					}
+					return table{header: util.SvcHeader, lines: lines, objects: objects}, nil
+				})
+				return
 			}
 
-			serviceResults := resources.GetServicesandPods(searchOptions, keyword)
+			serviceResults, err := resources.GetServicesandPods(searchOptions, keywords, showEndpoints)
+			exitOnError(err)
+			recordResults(len(serviceResults))
 
 			templates := &promptui.SelectTemplates{
 				Active:   "{{ .Service.Name | underline | yellow }}",
 				Inactive: "{{ .Service.Name }}",
 				Details: `
+-------- Service --------
+` + util.SvcHeader + `
+{{ .StatusLine }}
 --------- Pods ----------
 {{ .Headerline }}{{ range $i, $pod := .PodResponse }}
 {{ .StatusLine }}{{end}}`,
 			}
+			if showEndpoints {
+				templates.Details += `
+------- Endpoints -------{{ range .EndpointLines }}
+{{ . }}{{else}}
+<none>{{end}}`
+			}
 
 			prompt := promptui.Select{
 				Label:     "SERVICE NAME",
@@ -50,7 +80,8 @@ var (
 			if err != nil {
 				return
 			}
-			output := util.RawK8sOutput(namespace, context, labels, "get", "service", serviceResults[i].Service.Name, "-oyaml")
+			output, err := util.ClientFor(searchOptions).RawK8sOutput(serviceResults[i].Service.Namespace, searchOptions.Context, "", searchOptions.Kubeconfig, searchOptions.Timeout, "get", "service", serviceResults[i].Service.Name, "-oyaml")
+			exitOnError(err)
 			for _, line := range output {
 				fmt.Println(line)
 			}
@@ -58,6 +89,26 @@ var (
 	}
 )
 
+// serviceTypes - the values accepted by --type
+var serviceTypes = []string{"ClusterIP", "NodePort", "LoadBalancer", "ExternalName"}
+
+// validateServiceType - fail on a --type that no service can have
+func validateServiceType(serviceType string) error {
+	if serviceType == "" {
+		return nil
+	}
+	for _, t := range serviceTypes {
+		if strings.EqualFold(t, serviceType) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --type %q, expected one of: %s", serviceType, strings.Join(serviceTypes, "|"))
+}
+
 func init() {
+	serviceCmd.Flags().StringVar(&searchOptions.ServiceType, "type", "",
+		"Only show services of this type, one of: ClusterIP|NodePort|LoadBalancer|ExternalName, e.g. --type=LoadBalancer -A to find everything exposed outside the cluster.")
+	serviceCmd.Flags().BoolVar(&showEndpoints, "endpoints", false,
+		"If present, also show the endpoint addresses backing each service and whether they are ready.")
 	rootCmd.AddCommand(serviceCmd)
 }
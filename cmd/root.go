@@ -3,52 +3,330 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/mateo1647/kk/internal/config"
 	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
 	homedir "github.com/mitchellh/go-homedir"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 var cfgFile string
-var namespace string
-var context string
-var kubeconfig string
-var labels string
+
+// dryRun - print the kubectl commands kk would run instead of running them
+var dryRun bool
+
+// logFormat and logLevel - how and how much kk logs to stderr
+var logFormat, logLevel string
+
+// verbosity - how many times -v was given, see raiseLogLevel
+var verbosity int
 
 var rootCmd = &cobra.Command{
 	Use:   "kk",
 	Short: "make kubectl moar easier",
 	Long:  `a CLI to make kubectl commands easier`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		exitOnError(util.SetLogFormat(logFormat))
+		exitOnError(util.SetLogLevel(logLevel))
+		raiseLogLevel(verbosity)
+		exitOnError(initConfig(cmd.Flags()))
+		exitOnError(validateOutput())
+		exitOnError(validateContexts(cmd))
+		exitOnError(validateWatch())
+		exitOnError(util.ValidateSelector(searchOptions.Selector))
+		if outputOptions.NoColor {
+			util.DisableColor()
+		}
+		if outputOptions.NoHeaders {
+			util.DisableHeaders()
+		}
+		exitOnError(util.SetAgeFormat(outputOptions.AgeFormat))
+		if dryRun {
+			util.EnableDryRun()
+		}
+		if outputFile != "" {
+			exitOnError(util.StartOutputFile(outputFile, watchResults))
+		}
+		if quiet {
+			exitOnError(util.DiscardOutput())
+		}
+		if usePager(cmd) {
+			util.StartPager()
+		}
+		// with --contexts every context searched gets its own client, see runContexts
+		if !isMultiContext() {
+			exitOnError(util.InitClient(searchOptions))
+			exitOnError(util.ClientFor(searchOptions).CheckNamespaces(searchOptions))
+		}
+	},
 }
 
+// exit codes, so scripts can tell an empty search from a failed one
+const (
+	exitMatched = 0 // the search found something
+	exitNoMatch = 1 // the search ran but nothing matched
+	exitError   = 2 // the search itself failed: bad flags, no cluster, API errors
+)
+
+// exit - let the pager show everything printed, then exit with code
+func exit(code int) {
+	util.StopPager()
+	util.StopOutputFile()
+	os.Exit(code)
+}
+
+// searched and matched - recorded by printResults for the exit code
+var searched, matched bool
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, err)
+		exit(exitError)
 	}
+	if searched && !matched {
+		exit(exitNoMatch)
+	}
+	exit(exitMatched)
+}
+
+// raiseLogLevel - turn on debug logs for -v, and trace logs, including every
+// request to the API server, for -vv. It never lowers --log-level.
+func raiseLogLevel(verbosity int) {
+	level := log.GetLevel()
+	switch {
+	case verbosity >= 2:
+		level = log.TraceLevel
+	case verbosity == 1:
+		level = log.DebugLevel
+	}
+	if level > log.GetLevel() {
+		log.SetLevel(level)
+	}
+}
+
+// recordResults - note whether a search found anything, for the exit code
+func recordResults(found int) {
+	searched = true
+	matched = matched || found > 0
+}
+
+// searchKeywords - the search keywords given as arguments, with their
+// quotes removed. A resource matches any of them, or all of them with --all.
+func searchKeywords(args []string) []string {
+	var keywords []string
+	for _, arg := range args {
+		if keyword := util.TrimQuoteAndSpace(arg); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	searchedKeywords = keywords
+	return keywords
+}
+
+// searchedKeywords - the keywords of the running search, for printResults to
+// show the line they matched in with --deep
+var searchedKeywords []string
+
+// exitOnError - report a failed query to the user and exit non-zero, so a
+// failure isn't mistaken for an empty search result
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+	if util.IsTimeout(err) {
+		fmt.Fprintf(os.Stderr, "Error: the API server did not respond in time (--timeout=%v): %v\n", searchOptions.Timeout, err)
+		exit(exitError)
+	}
+	if apierrors.IsBadRequest(err) && searchOptions.FieldSelector != "" {
+		fmt.Fprintf(os.Stderr, "Error: the API server rejected --field-selector=%q: %v\n", searchOptions.FieldSelector, err)
+		exit(exitError)
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	exit(exitError)
 }
 
 // generic search options handler
 var searchOptions = options.NewSearchOptions()
 
+// output format handler
+var outputOptions = options.NewOutputOptions()
+
 func init() {
+	cfg := config.Get()
+
 	// Global Flags
-	rootCmd.PersistentFlags().StringVarP(
-		&searchOptions.Namespace, "namespace", "n", "",
-		"Namespace for search. (default: \"default\")")
+	rootCmd.PersistentFlags().StringVar(
+		&cfgFile, "config", "",
+		"Config file with defaults for namespace, context, output, no-color and fuzzy. (default: ~/.kk/config.yaml)")
+	rootCmd.PersistentFlags().StringSliceVarP(
+		&searchOptions.Namespaces, "namespace", "n", nil,
+		"Namespace(s) for search, comma separated. (default: $KK_NAMESPACE, $POD_NAMESPACE, the config file, then the context's namespace)")
 	rootCmd.PersistentFlags().BoolVarP(
 		&searchOptions.AllNamespaces, "all-namespaces", "A", false,
 		"If present, list the requested object(s) across all namespaces.")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.FuzzyNamespace, "fuzzy-namespace", false,
+		"If present, a namespace given with -n that doesn't exist is replaced by the existing one it fuzzy matches best, e.g. -n prod for production. Fails listing the candidates when several match equally well.")
+	rootCmd.PersistentFlags().StringVar(
+		&searchOptions.Kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use. (default: $KUBECONFIG, then ~/.kube/config)")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.InCluster, "in-cluster", cfg.InCluster,
+		"If present, use the service account of the pod kk runs in. (env: KK_IN_CLUSTER)")
+	rootCmd.PersistentFlags().StringVar(
+		&searchOptions.Context, "context", "",
+		"The name of the kubeconfig context to use. (default: the current-context)")
+	rootCmd.PersistentFlags().StringVar(
+		&searchOptions.As, "as", "",
+		"Username to impersonate for the search, e.g. --as=system:serviceaccount:team-a:deployer to see what that service account can list.")
+	rootCmd.PersistentFlags().StringArrayVar(
+		&searchOptions.AsGroups, "as-group", nil,
+		"Group to impersonate for the search along with --as, this flag can be repeated to specify multiple groups.")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false,
+		"If present, the server's certificate will not be checked for validity. This makes your HTTPS connections insecure, kk warns every time it is used.")
+	rootCmd.PersistentFlags().StringVar(
+		&searchOptions.CertificateAuthority, "certificate-authority", "",
+		"Path to a cert file to verify the API server's certificate with, instead of the kubeconfig's certificate authority.")
+	// complete namespaces and contexts with the functions in bashCompletionFunc
+	rootCmd.PersistentFlags().SetAnnotation("namespace", cobra.BashCompCustom, []string{"__kk_get_namespaces"})
+	rootCmd.PersistentFlags().SetAnnotation("context", cobra.BashCompCustom, []string{"__kk_get_contexts"})
 	rootCmd.PersistentFlags().StringVarP(
 		&searchOptions.Selector, "selector", "l", "",
-		"Selector (label query) to filter on. (e.g. -l key1=value1,key2=value2)")
+		"Selector (label query) to filter on, supports '=', '==', '!=', 'in', 'notin', 'key' and '!key'. (e.g. -l key1=value1,key2=value2 or -l 'env in (prod,staging),!canary')")
 	rootCmd.PersistentFlags().StringVar(
 		&searchOptions.FieldSelector, "field-selector", "",
 		"Selector (field query) to filter on. (e.g. --field-selector key1=value1,key2=value2)")
+	rootCmd.PersistentFlags().StringArrayVar(
+		&searchOptions.Annotations, "annotation", nil,
+		"Annotation to filter on, client-side: key to require the annotation, key=value to require its value to contain value. Repeat to require several.")
+	rootCmd.PersistentFlags().IntVar(
+		&searchOptions.Concurrency, "concurrency", cfg.Concurrency,
+		"Number of namespaces listed in parallel, fanning out --all-namespaces when above 1. (env: KK_CONCURRENCY)")
+	rootCmd.PersistentFlags().Int64Var(
+		&searchOptions.ChunkSize, "chunk-size", 500,
+		"Fetch large lists in pages of this many items, 0 to disable paging.")
+	rootCmd.PersistentFlags().DurationVar(
+		&searchOptions.Timeout, "timeout", 0,
+		"How long to wait for each API request before giving up, e.g. 30s. 0 waits forever.")
+	rootCmd.PersistentFlags().DurationVar(
+		&searchOptions.CacheTTL, "cache-ttl", cfg.CacheTTL,
+		"Reuse list results cached under ~/.kk/cache for this long, e.g. 10s. 0 disables the cache. (env: KK_CACHE_TTL)")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.NoCache, "no-cache", false,
+		"If present, always query the API server, ignoring --cache-ttl.")
+	rootCmd.PersistentFlags().Float32Var(
+		&searchOptions.QPS, "qps", cfg.QPS,
+		"Maximum queries per second to the API server, 0 for the client-go default of 5. (env: KK_QPS)")
+	rootCmd.PersistentFlags().IntVar(
+		&searchOptions.Burst, "burst", cfg.Burst,
+		"Maximum burst of queries to the API server, 0 for the client-go default of 10. (env: KK_BURST)")
+	rootCmd.PersistentFlags().IntVar(
+		&searchOptions.Retries, "retries", cfg.Retries,
+		"Number of times a list failing with a transient error (throttling, timeouts, refused or dropped connections) is retried, with exponential backoff. 0 disables retries. (env: KK_RETRIES)")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.Fuzzy, "fuzzy", false,
+		"If present, match names by subsequence and rank the best matches first.")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.CaseSensitive, "case-sensitive", false,
+		"If present, fuzzy matching is case-sensitive.")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.Regex, "regex", false,
+		"If present, the search keyword is a regular expression matched against names. (e.g. '^api-(v1|v2)-.*')")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.Deep, "deep", false,
+		"If present, also search the whole YAML of every object, like grep -r, and show the line that matched. Slower, every object is serialized.")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.MatchAll, "all", false,
+		"If present, several search keywords must all match, instead of any of them. (e.g. kk pod api prod --all)")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.MatchAll, "and", false,
+		"Same as --all.")
+	rootCmd.PersistentFlags().MarkHidden("and")
+	rootCmd.PersistentFlags().StringSliceVar(
+		&searchOptions.Exclude, "exclude", nil,
+		"Comma separated list of text to drop matches by: resources whose name contains any of it are left out, e.g. --exclude=canary,test. Regular expressions with --regex.")
+	rootCmd.PersistentFlags().StringVar(
+		&searchOptions.YoungerThan, "younger-than", "",
+		"Only show resources created less than this long ago, e.g. 1h or 7d.")
+	rootCmd.PersistentFlags().StringVar(
+		&searchOptions.OlderThan, "older-than", "",
+		"Only show resources created more than this long ago, e.g. 30d. With --younger-than, selects the window between the two.")
+	rootCmd.PersistentFlags().StringVar(
+		&searchOptions.SortBy, "sort-by", "",
+		"Sort results by one of: name|namespace|age|restarts|status. (default: name, or best match first with --fuzzy)")
+	rootCmd.PersistentFlags().BoolVar(
+		&searchOptions.Reverse, "reverse", false,
+		"If present, reverse the sort order, e.g. newest first with --sort-by=age.")
+	rootCmd.PersistentFlags().BoolVarP(
+		&watchResults, "watch", "w", false,
+		"If present, keep the results on screen and redraw them whenever a matching object changes.")
+	rootCmd.PersistentFlags().BoolVar(
+		&watchEvents, "only-events", false,
+		"If present with --watch, print a line with the time, change and name of every matching object added, modified or deleted instead of redrawing the table.")
+	rootCmd.PersistentFlags().StringVarP(
+		&outputOptions.Format, "output", "o", "",
+		"Output format. One of: wide|json|yaml|name|csv|tsv|custom-columns=<HEADER>:<json-path>,...|go-template=<template>|go-template-file=<path>. wide adds extra columns to the table, like kubectl; name prints kind/name, e.g. pod/api-1; csv and tsv print the table's columns as separated values; go-template renders every object, e.g. -o go-template='{{.metadata.name}}{{\"\\n\"}}'.")
+	rootCmd.PersistentFlags().BoolVar(
+		&printKubectl, "print-kubectl", false,
+		"If present, print the kubectl get command of every match, shell quoted, instead of the table, e.g. to run it with other verbs or flags.")
+	rootCmd.PersistentFlags().StringVar(
+		&outputFile, "output-file", "",
+		"Write the output to this file instead of stdout, creating its parent directories, e.g. --output-file snapshots/pods.json -o json. With --watch every redraw is appended.")
+	rootCmd.PersistentFlags().BoolVarP(
+		&quiet, "quiet", "q", false,
+		"If present, print nothing to stdout and only exit with 0 when something matched, 1 when nothing did and 2 when the search failed, e.g. kk pod api -q && echo found. Errors are still printed to stderr.")
+	rootCmd.PersistentFlags().StringSliceVarP(
+		&outputOptions.LabelColumns, "label-columns", "L", nil,
+		"Comma separated list of labels to show as extra columns, e.g. -L app,team. Objects without the label show a blank cell.")
+	rootCmd.PersistentFlags().StringVar(
+		&outputOptions.Columns, "columns", "",
+		"Comma separated list of <HEADER>=<json-path> columns to add to the table, e.g. --columns IP=.status.podIP,QOS=.status.qosClass. Objects without the field show a blank cell.")
+	rootCmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"If present, print the kubectl command kk would run, shell quoted, instead of running it. The search itself still queries the cluster.")
+	rootCmd.PersistentFlags().BoolVar(
+		&outputOptions.Count, "count", false,
+		"If present, only print how many resources matched.")
+	rootCmd.PersistentFlags().StringSliceVar(
+		&outputOptions.Summary, "summary", nil,
+		"Print how many resources matched per group instead of the table. One or both of: namespace|status. (default namespace,status when given without a value)")
+	rootCmd.PersistentFlags().Lookup("summary").NoOptDefVal = "namespace,status"
+	rootCmd.PersistentFlags().StringVar(
+		&outputOptions.AgeFormat, "age-format", "long",
+		"How to show ages. One of: long (3d4h)|short (3d)|absolute (the RFC3339 timestamp).")
+	rootCmd.PersistentFlags().BoolVar(
+		&outputOptions.NoColor, "no-color", false,
+		"If present, don't highlight the matched text. Color is also off when stdout isn't a terminal.")
+	rootCmd.PersistentFlags().BoolVar(
+		&outputOptions.NoHeaders, "no-headers", false,
+		"If present, don't print the header row of tables, e.g. for awk or cut. Also applies to -o wide, -L and custom-columns.")
+	rootCmd.PersistentFlags().StringVar(
+		&logFormat, "log-format", cfg.LogFormat,
+		"Format of the log lines written to stderr. One of: text|json. (env: KK_LOG_FORMAT)")
+	rootCmd.PersistentFlags().StringVar(
+		&logLevel, "log-level", cfg.LogLevel,
+		"Lowest level logged to stderr. One of: trace|debug|info|warning|error. debug shows why a list failed or matched nothing. (env: KK_LOG_LEVEL)")
+	rootCmd.PersistentFlags().CountVarP(
+		&verbosity, "verbose", "v",
+		"Log more: -v logs at debug level, -vv at trace level, which also logs every request to the API server with its status and duration.")
 }
 
-func initConfig() {
+// configKeys - the flags whose defaults can be set in the config file or
+// with KK_<FLAG> env vars, e.g. KK_NO_COLOR=true
+var configKeys = []string{"namespace", "context", "output", "no-color", "fuzzy"}
+
+// initConfig - read ~/.kk/config.yaml, or the file given with --config, and
+// use it and the KK_* env vars as defaults for the flags left unset. A
+// missing default config file is fine.
+func initConfig(flags *pflag.FlagSet) error {
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -56,29 +334,48 @@ func initConfig() {
 		// Find home directory.
 		home, err := homedir.Dir()
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			return err
 		}
 
-		// Search config in home directory with name ".kk" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".kk")
+		// Search config in ~/.kk with name "config" (without extension).
+		viper.AddConfigPath(filepath.Join(home, ".kk"))
+		viper.SetConfigName("config")
 	}
 
+	viper.SetEnvPrefix("kk")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return fmt.Errorf("reading config file: %v", err)
+		}
+	} else {
+		log.WithFields(log.Fields{
+			"file": viper.ConfigFileUsed(),
+		}).Debug("Using config file")
 	}
 
-	if kubeconfig == "" {
-		if kenv := os.Getenv("KUBECONFIG"); kenv != "" {
-			kubeconfig = kenv
-		} else if h := os.Getenv("HOME"); h != "" {
-			kubeconfig = fmt.Sprintf("%v/.kube/config", h)
-		} else {
-			panic(fmt.Errorf("error setting default kubeconfig. $HOME not set"))
+	// flags given on the command line win over env vars, which win over the file
+	for _, key := range configKeys {
+		flag := flags.Lookup(key)
+		if flag == nil || flag.Changed || !viper.IsSet(key) {
+			continue
+		}
+		if _, fromEnv := os.LookupEnv("KK_NAMESPACE"); key == "namespace" && !fromEnv {
+			// $POD_NAMESPACE wins over the namespace of the file, so it is
+			// kept apart from -n
+			searchOptions.DefaultNamespaces = viper.GetStringSlice(key)
+			continue
+		}
+		value := viper.GetString(key)
+		if flag.Value.Type() == "stringSlice" {
+			value = strings.Join(viper.GetStringSlice(key), ",")
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid %s %q in config: %v", key, value, err)
 		}
 	}
+	return nil
 }
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestInitConfigNamespace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kk-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(file, []byte("namespace: [team-a]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func(old string) { cfgFile = old }(cfgFile)
+	cfgFile = file
+	defer func(old options.SearchOptions) { *searchOptions = old }(*searchOptions)
+	defer os.Setenv("KK_NAMESPACE", os.Getenv("KK_NAMESPACE"))
+
+	tests := []struct {
+		name         string
+		args         []string
+		env          string
+		wantFlag     []string
+		wantDefaults []string
+	}{
+		{name: "config file kept apart from -n", wantDefaults: []string{"team-a"}},
+		{name: "$KK_NAMESPACE before config file", env: "team-b", wantFlag: []string{"team-b"}},
+		{name: "-n before $KK_NAMESPACE", args: []string{"-n", "team-c"}, env: "team-b", wantFlag: []string{"team-c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			*searchOptions = options.SearchOptions{}
+			if tt.env != "" {
+				os.Setenv("KK_NAMESPACE", tt.env)
+			} else {
+				os.Unsetenv("KK_NAMESPACE")
+			}
+			flags := pflag.NewFlagSet("kk", pflag.ContinueOnError)
+			flags.StringSliceVarP(&searchOptions.Namespaces, "namespace", "n", nil, "")
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := initConfig(flags); err != nil {
+				t.Fatalf("initConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(searchOptions.Namespaces, tt.wantFlag) {
+				t.Errorf("Namespaces = %q, want %q", searchOptions.Namespaces, tt.wantFlag)
+			}
+			if !reflect.DeepEqual(searchOptions.DefaultNamespaces, tt.wantDefaults) {
+				t.Errorf("DefaultNamespaces = %q, want %q", searchOptions.DefaultNamespaces, tt.wantDefaults)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	secretCmd = &cobra.Command{
+		Use:     "secret",
+		Aliases: []string{"secrets"},
+		Short:   "Search secrets by name",
+		Long: `lists the keys of matching secrets with the size of each value;
+--show-values prints the decoded values instead. --key and --value-contains
+only list the keys that match, values stay redacted unless --show-values`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			header := util.SecretKeyHeader
+			if searchOptions.ShowValues {
+				header = util.SecretValueHeader
+			}
+
+			runOrWatch("secrets", func(opt *options.SearchOptions) (table, error) {
+				secretResults, err := resources.GetSecrets(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var rowObjects, objects []runtime.Object
+				for i := range secretResults {
+					for _, line := range resources.NewSecretKeyDetails(secretResults[i].Secret, secretResults[i].Keys, opt.ShowValues) {
+						lines = append(lines, secretResults[i].Match.Highlight(line))
+						rowObjects = append(rowObjects, &secretResults[i].Secret)
+					}
+					objects = append(objects, &secretResults[i].Secret)
+				}
+				return table{header: header, lines: lines, rowObjects: rowObjects, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	addDataFlags(secretCmd)
+	secretCmd.Flags().BoolVar(&searchOptions.ShowValues, "show-values", false,
+		"Print the decoded value of each key instead of its size. Values that aren't valid UTF-8 are shown as <binary: N bytes>. With --deep, values are only searched and shown in MATCHED LINE when this is set.")
+	rootCmd.AddCommand(secretCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceAccountCmd = &cobra.Command{
+		Use:     "serviceaccount",
+		Aliases: []string{"serviceaccounts", "sa"},
+		Short:   "Search service accounts by name",
+		Long:    `lists service accounts with the number of mounted secrets and image pull secrets`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("serviceaccounts", func(opt *options.SearchOptions) (table, error) {
+				serviceAccountResults, err := resources.GetServiceAccounts(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range serviceAccountResults {
+					lines = append(lines, serviceAccountResults[i].StatusLine)
+					objects = append(objects, &serviceAccountResults[i].ServiceAccount)
+				}
+				return table{header: util.ServiceAccountHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(serviceAccountCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statefulSetCmd = &cobra.Command{
+		Use:     "statefulset",
+		Aliases: []string{"statefulsets", "sts"},
+		Short:   "Search statefulsets by name",
+		Long:    `lists statefulsets with their ready replicas and their current and update revisions`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("statefulsets", func(opt *options.SearchOptions) (table, error) {
+				statefulSetResults, err := resources.GetStatefulSets(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				header := util.StatefulsetHeader
+				// the wide table has an images column already
+				addImages := showImages && !outputOptions.IsWide()
+				if outputOptions.IsWide() {
+					header = util.StatefulsetHeaderWide
+				}
+				if addImages {
+					header += "\t" + util.ImagesColumn
+				}
+				for i := range statefulSetResults {
+					line := statefulSetResults[i].StatusLine
+					if outputOptions.IsWide() {
+						line = statefulSetResults[i].Match.Highlight(resources.NewStatefulSetDetailsWide(statefulSetResults[i].StatefulSet))
+					}
+					if addImages {
+						line += "\t" + resources.ContainerImages(statefulSetResults[i].StatefulSet.Spec.Template.Spec)
+					}
+					lines = append(lines, line)
+					objects = append(objects, &statefulSetResults[i].StatefulSet)
+				}
+				return table{header: header, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	statefulSetCmd.Flags().BoolVar(&showImages, "show-images", false,
+		"If present, add a column with the image of every container in the pod template.")
+	statefulSetCmd.Flags().StringVar(&searchOptions.Image, "image", "",
+		"Only show statefulsets with a container whose image contains this text, e.g. --image=postgres.")
+	statefulSetCmd.Flags().BoolVar(&searchOptions.NotRolledOut, "not-rolled-out", false,
+		"If present, only show statefulsets with pods still on an older revision, where the current and update revisions differ.")
+	rootCmd.AddCommand(statefulSetCmd)
+}
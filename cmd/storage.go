@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	storageClassCmd = &cobra.Command{
+		Use:     "storageclass",
+		Aliases: []string{"storageclasses", "sc"},
+		Short:   "Search storage classes by name or provisioner",
+		Long:    `lists storage classes with their provisioner, reclaim policy, binding mode and which one is the default`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("storageclasses", func(opt *options.SearchOptions) (table, error) {
+				storageClassResults, err := resources.GetStorageClasses(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range storageClassResults {
+					lines = append(lines, storageClassResults[i].StatusLine)
+					objects = append(objects, &storageClassResults[i].StorageClass)
+				}
+				return table{header: util.StorageClassHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	volumeAttachmentCmd = &cobra.Command{
+		Use:     "volumeattachment",
+		Aliases: []string{"volumeattachments", "va"},
+		Short:   "Search volume attachments by name, volume or node",
+		Long:    `lists volume attachments with their attacher, volume, node and whether the volume is attached`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("volumeattachments", func(opt *options.SearchOptions) (table, error) {
+				attachmentResults, err := resources.GetVolumeAttachments(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range attachmentResults {
+					lines = append(lines, attachmentResults[i].StatusLine)
+					objects = append(objects, &attachmentResults[i].VolumeAttachment)
+				}
+				return table{header: util.VaHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(storageClassCmd)
+	rootCmd.AddCommand(volumeAttachmentCmd)
+}
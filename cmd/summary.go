@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// summaryKeys - the values accepted by --summary
+var summaryKeys = []string{"namespace", "status"}
+
+// printSummary - print how many of objects fall in each group of the
+// --summary keys, e.g. per namespace and status
+func printSummary(keys []string, objects []runtime.Object) {
+	if len(objects) == 0 {
+		fmt.Println("No resources found.")
+		return
+	}
+
+	counts := map[string]int{}
+	for _, obj := range objects {
+		var group []string
+		for _, key := range keys {
+			group = append(group, summaryValue(key, obj))
+		}
+		counts[strings.Join(group, "\t")]++
+	}
+
+	groups := make([]string, 0, len(counts))
+	for group := range counts {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var lines []string
+	for _, group := range groups {
+		lines = append(lines, fmt.Sprintf("%s\t%d", group, counts[group]))
+	}
+	header := strings.ToUpper(strings.Join(keys, "\t")) + "\tCOUNT"
+	util.PrintTable(header, lines)
+	fmt.Printf("\n%d total\n", len(objects))
+}
+
+// summaryValue - the group obj falls in for one --summary key
+func summaryValue(key string, obj runtime.Object) string {
+	var value string
+	switch key {
+	case "namespace":
+		if accessor, err := meta.Accessor(obj); err == nil {
+			value = accessor.GetNamespace()
+		}
+	case "status":
+		value = resources.ObjectStatus(obj)
+	}
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+// validateSummary - fail on an unknown --summary key before anything is queried
+func validateSummary() error {
+	for _, key := range outputOptions.Summary {
+		if !contains(summaryKeys, key) {
+			return fmt.Errorf("invalid --summary %q, expected a comma separated list of: %s",
+				key, strings.Join(summaryKeys, "|"))
+		}
+	}
+	return nil
+}
+
+// contains - report whether list has s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
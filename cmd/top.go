@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	topCmd = &cobra.Command{
+		Use:   "top",
+		Short: "Show the CPU and memory usage of resources",
+		Long:  `shows resource usage from the metrics API, which needs metrics-server`,
+	}
+
+	topPodCmd = &cobra.Command{
+		Use:     "pod",
+		Aliases: []string{"pods", "po"},
+		Short:   "Search pods by name and show their CPU and memory usage",
+		Long: `lists pods with their CPU and memory usage, and how much of their
+requests and limits that is`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("pods", func(opt *options.SearchOptions) (table, error) {
+				topResults, err := resources.GetTopPods(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range topResults {
+					lines = append(lines, topResults[i].StatusLine)
+					objects = append(objects, &topResults[i].Pod)
+				}
+				return table{header: util.TopPodHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	topCmd.AddCommand(topPodCmd)
+	rootCmd.AddCommand(topCmd)
+}
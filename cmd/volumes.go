@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pvcCmd = &cobra.Command{
+		Use:     "pvc",
+		Aliases: []string{"persistentvolumeclaim", "persistentvolumeclaims"},
+		Short:   "Search persistent volume claims by name or storage class",
+		Long:    `lists persistent volume claims with their bound volume, requested capacity, access modes and storage class`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("persistentvolumeclaims", func(opt *options.SearchOptions) (table, error) {
+				pvcResults, err := resources.GetPersistentVolumeClaims(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range pvcResults {
+					lines = append(lines, pvcResults[i].StatusLine)
+					objects = append(objects, &pvcResults[i].PersistentVolumeClaim)
+				}
+				return table{header: util.PvcHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+
+	pvCmd = &cobra.Command{
+		Use:     "pv",
+		Aliases: []string{"persistentvolume", "persistentvolumes"},
+		Short:   "Search persistent volumes by name, claim or storage class",
+		Long:    `lists persistent volumes with their capacity, reclaim policy, status and claim`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keywords := searchKeywords(args)
+
+			runOrWatch("persistentvolumes", func(opt *options.SearchOptions) (table, error) {
+				pvResults, err := resources.GetPersistentVolumes(opt, keywords)
+				if err != nil {
+					return table{}, err
+				}
+
+				var lines []string
+				var objects []runtime.Object
+				for i := range pvResults {
+					lines = append(lines, pvResults[i].StatusLine)
+					objects = append(objects, &pvResults[i].PersistentVolume)
+				}
+				return table{header: util.PvHeader, lines: lines, objects: objects}, nil
+			})
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(pvcCmd)
+	rootCmd.AddCommand(pvCmd)
+}
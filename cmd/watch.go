@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mateo1647/kk/resources"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var (
+	watchResults bool
+	watchEvents  bool
+)
+
+// watchRedrawDelay - how long to collect events before redrawing, so a burst
+// of changes results in a single redraw
+const watchRedrawDelay = 500 * time.Millisecond
+
+// runOrWatch - print what search finds once or, with --watch, redraw it
+// every time a watched resource is added, modified or deleted
+func runOrWatch(resource string, search searchFunc) {
+	exitOnError(util.ValidateFieldSelector(resource, searchOptions.FieldSelector))
+
+	if !watchResults {
+		runSearch(search)
+		return
+	}
+	if watchEvents {
+		logEvents(resource)
+		return
+	}
+	// every redraw has to see the change that triggered it
+	searchOptions.NoCache = true
+
+	redraw := func() {
+		if !outputOptions.IsMachine() && !util.WritingToFile() {
+			// clear the screen and move the cursor home
+			fmt.Print("\033[H\033[2J")
+		}
+		runSearch(search)
+	}
+	redraw()
+
+	changed := make(chan struct{}, 1)
+	go func() {
+		exitOnError(util.ClientFor(searchOptions).Watch(searchOptions, resource, func(watch.Event) {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}))
+	}()
+
+	for range changed {
+		time.Sleep(watchRedrawDelay)
+		select {
+		case <-changed:
+		default:
+		}
+		redraw()
+	}
+}
+
+// runSearch - print what search finds, in every context searched with
+// --contexts or --all-contexts at once
+func runSearch(search searchFunc) {
+	if isMultiContext() {
+		runContexts(search)
+	}
+	t, err := search(searchOptions)
+	exitOnError(err)
+	printTable(t)
+}
+
+// logEvents - print a line for every matching object added, modified or
+// deleted instead of redrawing the table, for --only-events
+func logEvents(resource string) {
+	matches, err := resources.ObjectFilter(searchOptions, searchedKeywords)
+	exitOnError(err)
+	// only what changes from now on is logged, not every existing object
+	exitOnError(util.ClientFor(searchOptions).WatchChanges(searchOptions, resource, func(ev watch.Event) {
+		if !matches(ev.Object) {
+			return
+		}
+		name, err := util.ObjectName(ev.Object)
+		if err != nil {
+			return
+		}
+		if accessor, err := meta.Accessor(ev.Object); err == nil && accessor.GetNamespace() != "" {
+			name = accessor.GetNamespace() + "/" + name
+		}
+		verb := fmt.Sprintf("%-8s", ev.Type)
+		if ev.Type == watch.Deleted {
+			verb = util.Deleted(verb)
+			name = util.Deleted(name)
+		}
+		fmt.Printf("%s  %s  %s\n", time.Now().UTC().Format(time.RFC3339), verb, name)
+	}))
+}
+
+// validateWatch - fail on --only-events without a watch to log, or with an
+// output the event lines can't be written in
+func validateWatch() error {
+	if !watchEvents {
+		return nil
+	}
+	if !watchResults {
+		return fmt.Errorf("--only-events requires --watch")
+	}
+	if outputOptions.Format != "" || outputOptions.IsAggregate() {
+		return fmt.Errorf("--only-events prints one line per change and can't be combined with -o, --count or --summary")
+	}
+	return nil
+}
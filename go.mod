@@ -10,6 +10,7 @@ require (
 	github.com/guessi/kubectl-grep v1.2.4
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/manifoldco/promptui v0.7.0
+	github.com/mattn/go-isatty v0.0.11
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/pkg/errors v0.8.0
 	github.com/sirupsen/logrus v1.5.0
@@ -21,6 +22,7 @@ require (
 	k8s.io/cli-runtime v0.0.0-20190918162238-f783a3654da8
 	k8s.io/client-go v0.16.8
 	sigs.k8s.io/kustomize v2.0.3+incompatible
+	sigs.k8s.io/yaml v1.1.0
 )
 
 replace (
@@ -2,17 +2,25 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
 // Config is the env config; can be overwritten with env vars
 type Config struct {
-	LogLevel        string   `default:"debug" envconfig:"LOG_LEVEL"` // debugging
-	EnvMatchRegex   string   `default:".*"`
-	KubeClusterName string   `default:""`
-	KubeConfig      string   `default:"" envconfig:"KUBECONFIG"`
-	AllowedOrigins  []string `default:"*"`
+	LogLevel        string        `default:"info" envconfig:"LOG_LEVEL"` // debugging
+	LogFormat       string        `default:"text" envconfig:"LOG_FORMAT"`
+	EnvMatchRegex   string        `default:".*"`
+	KubeClusterName string        `default:""`
+	KubeConfig      string        `default:"" envconfig:"KUBECONFIG"`
+	AllowedOrigins  []string      `default:"*"`
+	Concurrency     int           `default:"1" envconfig:"CONCURRENCY"` // parallel list calls
+	InCluster       bool          `default:"false" envconfig:"IN_CLUSTER"`
+	QPS             float32       `default:"0" envconfig:"QPS"`       // 0 keeps the client-go default
+	Burst           int           `default:"0" envconfig:"BURST"`     // 0 keeps the client-go default
+	Retries         int           `default:"3" envconfig:"RETRIES"`   // retries of transient list failures
+	CacheTTL        time.Duration `default:"0" envconfig:"CACHE_TTL"` // 0 disables the list cache
 }
 
 // Get returns the environment configuration
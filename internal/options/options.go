@@ -1,13 +1,171 @@
 package options
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type SearchOptions struct {
 	AllNamespaces bool
-	Namespace     string
+	Namespaces    []string
 	Selector      string
 	FieldSelector string
+	Annotations   []string
+	Image         string
+	Status        string
+	NotReady      bool
+	MinRestarts   int32
+	NodeSelector  string
+	Toleration    string
+	Taint         string
+	NotRolledOut  bool
+	ServiceType   string
+	YoungerThan   string
+	OlderThan     string
+	Since         string
+	Concurrency   int
+	Fuzzy         bool
+	MatchAll      bool
+	Exclude       []string
+	CaseSensitive bool
+	Regex         bool
+	Deep          bool
+	SortBy        string
+	Reverse       bool
+	Context       string
+	Kubeconfig    string
+	InCluster     bool
+	QPS           float32
+	Burst         int
+	Timeout       time.Duration
+	Retries       int
+	ChunkSize     int64
+	CacheTTL      time.Duration
+	NoCache       bool
+
+	// FuzzyNamespace resolves a namespace that doesn't exist to the one it
+	// is a subsequence of, e.g. prod to production
+	FuzzyNamespace bool
+
+	// DefaultNamespaces are the namespaces of the config file, searched
+	// without -n, $KK_NAMESPACE or $POD_NAMESPACE
+	DefaultNamespaces []string
+
+	// InsecureSkipTLSVerify and CertificateAuthority override how the
+	// certificate of the API server is verified, like kubectl's flags
+	InsecureSkipTLSVerify bool
+	CertificateAuthority  string
+
+	// As and AsGroups impersonate another user and its groups, like
+	// kubectl --as and --as-group
+	As       string
+	AsGroups []string
+
+	// DataKey and ValueContains only keep the configmaps and secrets with a
+	// key of that name, or a value containing that text
+	DataKey       string
+	ValueContains string
+
+	// ShowValues prints the values of configmaps and secrets instead of
+	// their size, and lets --deep search and print the values of secrets
+	ShowValues bool
 }
 
 // NewSearchOptions - genericclioptions wrapper for searchOptions
 func NewSearchOptions() *SearchOptions {
 	return &SearchOptions{}
 }
+
+// FiltersData - report whether configmaps and secrets are filtered on the
+// keys or values of their data
+func (o *SearchOptions) FiltersData() bool {
+	return o.DataKey != "" || o.ValueContains != ""
+}
+
+type OutputOptions struct {
+	Format       string
+	NoColor      bool
+	NoHeaders    bool
+	LabelColumns []string
+	Columns      string
+	Count        bool
+	Summary      []string
+	AgeFormat    string
+}
+
+// NewOutputOptions - options controlling how matched resources are printed
+func NewOutputOptions() *OutputOptions {
+	return &OutputOptions{}
+}
+
+// Validate - reject unknown output formats before any API call is made
+func (o *OutputOptions) Validate() error {
+	switch o.Format {
+	case "", "wide", "json", "yaml", "csv", "tsv", "name":
+		return nil
+	}
+	if spec, ok := o.CustomColumns(); ok {
+		if spec == "" {
+			return fmt.Errorf("custom-columns format specified but no custom columns given")
+		}
+		return nil
+	}
+	if _, _, ok := o.GoTemplate(); ok {
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, expected one of: wide|json|yaml|name|csv|tsv|custom-columns=|go-template=|go-template-file=", o.Format)
+}
+
+// IsMachine - report whether another format replaces the human readable table
+func (o *OutputOptions) IsMachine() bool {
+	return o.Format != "" && !o.IsWide()
+}
+
+// IsAggregate - report whether only counts of the matched objects are printed
+func (o *OutputOptions) IsAggregate() bool {
+	return o.Count || len(o.Summary) > 0
+}
+
+// IsDelimited - report whether the table is printed as comma or tab
+// separated values instead of aligned columns
+func (o *OutputOptions) IsDelimited() bool {
+	return o.Format == "csv" || o.Format == "tsv"
+}
+
+// IsName - report whether only kind/name of every object is printed, like
+// `kubectl get -o name`
+func (o *OutputOptions) IsName() bool {
+	return o.Format == "name"
+}
+
+// IsWide - report whether the table should show the extra `-o wide` columns
+func (o *OutputOptions) IsWide() bool {
+	return o.Format == "wide"
+}
+
+// CustomColumns - return the column spec of `-o custom-columns=<spec>`
+func (o *OutputOptions) CustomColumns() (string, bool) {
+	if !strings.HasPrefix(o.Format, customColumnsPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(o.Format, customColumnsPrefix), true
+}
+
+// GoTemplate - return the template of `-o go-template=<template>`, or the
+// path of the file holding it for `-o go-template-file=<path>`
+func (o *OutputOptions) GoTemplate() (text string, fromFile bool, ok bool) {
+	if strings.HasPrefix(o.Format, goTemplateFilePrefix) {
+		return strings.TrimPrefix(o.Format, goTemplateFilePrefix), true, true
+	}
+	if strings.HasPrefix(o.Format, goTemplatePrefix) {
+		return strings.TrimPrefix(o.Format, goTemplatePrefix), false, true
+	}
+	return "", false, false
+}
+
+const (
+	customColumnsPrefix  = "custom-columns="
+	goTemplatePrefix     = "go-template="
+	goTemplateFilePrefix = "go-template-file="
+)
@@ -2,34 +2,214 @@ package client
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
 	// gcp fails hard without this
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
 
-func ClientConfig() clientcmd.ClientConfig {
+// serviceAccountNamespace - where the namespace of a pod's service account is mounted
+const serviceAccountNamespace = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// Options - how to reach the cluster, mirroring kubectl's global flags
+type Options struct {
+	// Kubeconfig is an explicit kubeconfig file; when empty the KUBECONFIG
+	// env var and then ~/.kube/config are used
+	Kubeconfig string
+	// Context is the kubeconfig context to use instead of current-context
+	Context string
+	// InCluster forces the service account config of the pod kk runs in
+	InCluster bool
+	// QPS and Burst tune the client side rate limiter; 0 keeps the
+	// client-go defaults of 5 queries per second with bursts of 10
+	QPS   float32
+	Burst int
+	// Timeout bounds every request to the API server; 0 waits forever
+	Timeout time.Duration
+	// InsecureSkipTLSVerify doesn't check the certificate of the API server,
+	// CertificateAuthority checks it against this CA file instead of the
+	// one in the kubeconfig
+	InsecureSkipTLSVerify bool
+	CertificateAuthority  string
+	// As and AsGroups are the user and groups to impersonate
+	As       string
+	AsGroups []string
+}
+
+// ClientConfig - load the kubeconfig selected by opt
+func ClientConfig(opt Options) clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = opt.Kubeconfig
+
 	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		clientcmd.NewDefaultClientConfigLoadingRules(),
-		&clientcmd.ConfigOverrides{})
+		rules,
+		&clientcmd.ConfigOverrides{CurrentContext: opt.Context})
+}
+
+// RestConfig - resolve the config used to call the kube API. Inside a pod the
+// service account is used, unless a kubeconfig or context was asked for
+// explicitly; everywhere else the kubeconfig is loaded.
+func RestConfig(opt Options) (*rest.Config, error) {
+	if useInCluster(opt) {
+		config, err := rest.InClusterConfig()
+		if err == nil {
+			log.Debug("Using in-cluster service account config")
+			return config, nil
+		}
+		if opt.InCluster {
+			return nil, err
+		}
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("In-cluster config unavailable, falling back to kubeconfig")
+	}
+
+	config, err := ClientConfig(opt).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(log.Fields{
+		"kubeconfig": opt.Kubeconfig,
+		"context":    opt.Context,
+	}).Debug("Using kubeconfig")
+	return config, nil
+}
+
+// Namespace - the default namespace for searches: the pod's own namespace
+// when running in-cluster, otherwise the one set on the kubeconfig context
+func Namespace(opt Options) (string, error) {
+	if useInCluster(opt) {
+		if data, err := ioutil.ReadFile(serviceAccountNamespace); err == nil {
+			if ns := strings.TrimSpace(string(data)); ns != "" {
+				return ns, nil
+			}
+		}
+	}
+	ns, _, err := ClientConfig(opt).Namespace()
+	return ns, err
 }
 
-// get the kube client config to call kube API
-func InitClient() *kubernetes.Clientset {
-	clientConfig := ClientConfig()
-	config, err := clientConfig.ClientConfig()
+// Contexts - the names of the contexts in the kubeconfig, sorted
+func Contexts(opt Options) ([]string, error) {
+	raw, err := ClientConfig(opt).RawConfig()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: getting configurations is hard\n")
-		os.Exit(1)
+		return nil, err
+	}
+	names := make([]string, 0, len(raw.Contexts))
+	for name := range raw.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func useInCluster(opt Options) bool {
+	if opt.InCluster {
+		return true
+	}
+	return opt.Kubeconfig == "" && opt.Context == "" && os.Getenv("KUBERNETES_SERVICE_HOST") != ""
+}
+
+// Config - the rest config for opt with its rate limits and timeout applied
+func Config(opt Options) (*rest.Config, error) {
+	config, err := RestConfig(opt)
+	if err != nil {
+		return nil, fmt.Errorf("getting configurations is hard: %v", err)
+	}
+	if opt.QPS > 0 {
+		config.QPS = opt.QPS
 	}
+	if opt.Burst > 0 {
+		config.Burst = opt.Burst
+	}
+	config.Timeout = opt.Timeout
+	if err := configureTLS(config, opt); err != nil {
+		return nil, err
+	}
+	if len(opt.AsGroups) > 0 && opt.As == "" {
+		return nil, fmt.Errorf("--as-group needs --as, the user to impersonate")
+	}
+	if opt.As != "" {
+		config.Impersonate = rest.ImpersonationConfig{UserName: opt.As, Groups: opt.AsGroups}
+	}
+	if log.IsLevelEnabled(log.TraceLevel) {
+		config.Wrap(logRequests)
+	}
+	return config, nil
+}
+
+// warnInsecure - warn about --insecure-skip-tls-verify once, not for every
+// client built
+var warnInsecure sync.Once
+
+// configureTLS - apply --insecure-skip-tls-verify and --certificate-authority
+// to config, over what the kubeconfig sets
+func configureTLS(config *rest.Config, opt Options) error {
+	if opt.InsecureSkipTLSVerify && opt.CertificateAuthority != "" {
+		return fmt.Errorf("--insecure-skip-tls-verify and --certificate-authority can't be used together")
+	}
+	if opt.CertificateAuthority != "" {
+		if _, err := os.Stat(opt.CertificateAuthority); err != nil {
+			return fmt.Errorf("unable to read --certificate-authority: %v", err)
+		}
+		config.TLSClientConfig.CAFile = opt.CertificateAuthority
+		config.TLSClientConfig.CAData = nil
+	}
+	if opt.InsecureSkipTLSVerify {
+		// client-go refuses a CA together with insecure
+		config.TLSClientConfig.Insecure = true
+		config.TLSClientConfig.CAFile = ""
+		config.TLSClientConfig.CAData = nil
+		warnInsecure.Do(func() {
+			log.Warn("TLS certificate verification is disabled with --insecure-skip-tls-verify, the API server's identity isn't checked")
+		})
+	}
+	return nil
+}
+
+// InitClient - the typed clients for config, see Config
+func InitClient(config *rest.Config) (*kubernetes.Clientset, error) {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: creating clients is hard\n")
-		os.Exit(1)
+		return nil, fmt.Errorf("creating clients is hard: %v", err)
+	}
+
+	return clientset, nil
+}
+
+// InitTableClient - a client for raw requests whose responses are asked for
+// as accept, e.g. the tables the API server prints lists as
+func InitTableClient(config *rest.Config, accept string) (rest.Interface, error) {
+	config = rest.CopyConfig(config)
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &acceptRoundTripper{accept: accept, next: rt}
+	})
+	client, err := rest.UnversionedRESTClientFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating clients is hard: %v", err)
 	}
+	return client, nil
+}
 
-	return clientset
+// InitDynamicClient - a client for resources kk has no typed client for
+func InitDynamicClient(config *rest.Config) (dynamic.Interface, error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating clients is hard: %v", err)
+	}
+	return client, nil
 }
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// twoContexts - a kubeconfig with the contexts a and b, on different
+// clusters and with different default namespaces
+const twoContexts = `apiVersion: v1
+kind: Config
+current-context: a
+clusters:
+- name: cluster-a
+  cluster: {server: "https://a.example.com"}
+- name: cluster-b
+  cluster: {server: "https://b.example.com"}
+users:
+- name: user
+  user: {token: secret}
+contexts:
+- name: a
+  context: {cluster: cluster-a, user: user, namespace: ns-a}
+- name: b
+  context: {cluster: cluster-b, user: user, namespace: ns-b}
+`
+
+// envContext - a kubeconfig with a single context, found through $KUBECONFIG
+const envContext = `apiVersion: v1
+kind: Config
+current-context: env
+clusters:
+- name: cluster-env
+  cluster: {server: "https://env.example.com"}
+users:
+- name: user
+  user: {token: secret}
+contexts:
+- name: env
+  context: {cluster: cluster-env, user: user, namespace: ns-env}
+`
+
+// writeKubeconfig - write content to a kubeconfig file in dir
+func writeKubeconfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// setEnv - set key to value until the returned func restores it
+func setEnv(key, value string) func() {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+// testKubeconfigs - the kubeconfig with contexts a and b, with $KUBECONFIG
+// pointing at another one and kk not running in a pod
+func testKubeconfigs(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "kk-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	explicit := writeKubeconfig(t, dir, "explicit", twoContexts)
+	restoreKubeconfig := setEnv("KUBECONFIG", writeKubeconfig(t, dir, "env", envContext))
+	restoreInCluster := setEnv("KUBERNETES_SERVICE_HOST", "")
+	return explicit, func() {
+		restoreInCluster()
+		restoreKubeconfig()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRestConfigExplicitKubeconfig(t *testing.T) {
+	explicit, cleanup := testKubeconfigs(t)
+	defer cleanup()
+
+	tests := []struct {
+		name string
+		opt  Options
+		want string
+	}{
+		{name: "$KUBECONFIG", opt: Options{}, want: "https://env.example.com"},
+		{name: "--kubeconfig wins over $KUBECONFIG", opt: Options{Kubeconfig: explicit}, want: "https://a.example.com"},
+		{name: "--kubeconfig with --context", opt: Options{Kubeconfig: explicit, Context: "b"}, want: "https://b.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := RestConfig(tt.opt)
+			if err != nil {
+				t.Fatalf("RestConfig() error = %v", err)
+			}
+			if config.Host != tt.want {
+				t.Errorf("RestConfig() host = %q, want %q", config.Host, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextsExplicitKubeconfig(t *testing.T) {
+	explicit, cleanup := testKubeconfigs(t)
+	defer cleanup()
+
+	contexts, err := Contexts(Options{Kubeconfig: explicit})
+	if err != nil {
+		t.Fatalf("Contexts() error = %v", err)
+	}
+	if len(contexts) != 2 || contexts[0] != "a" || contexts[1] != "b" {
+		t.Errorf("Contexts() = %q, want [a b]", contexts)
+	}
+}
+
+func TestNamespaceOfContext(t *testing.T) {
+	explicit, cleanup := testKubeconfigs(t)
+	defer cleanup()
+
+	tests := []struct {
+		name string
+		opt  Options
+		want string
+	}{
+		{name: "current-context", opt: Options{Kubeconfig: explicit}, want: "ns-a"},
+		{name: "--context", opt: Options{Kubeconfig: explicit, Context: "b"}, want: "ns-b"},
+		{name: "$KUBECONFIG", opt: Options{}, want: "ns-env"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns, err := Namespace(tt.opt)
+			if err != nil {
+				t.Fatalf("Namespace() error = %v", err)
+			}
+			if ns != tt.want {
+				t.Errorf("Namespace() = %q, want %q", ns, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceOfContextFromEnv(t *testing.T) {
+	explicit, cleanup := testKubeconfigs(t)
+	defer cleanup()
+	defer setEnv("KUBECONFIG", explicit)()
+
+	ns, err := Namespace(Options{Context: "b"})
+	if err != nil {
+		t.Fatalf("Namespace() error = %v", err)
+	}
+	if ns != "ns-b" {
+		t.Errorf("Namespace(Options{Context: \"b\"}) = %q, want %q", ns, "ns-b")
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// loggingRoundTripper - log every request to the API server at trace level,
+// with its response status and how long it took
+type loggingRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := rt.next.RoundTrip(req)
+
+	fields := log.Fields{
+		"method":   req.Method,
+		"url":      req.URL.String(),
+		"duration": time.Since(start).String(),
+	}
+	if err != nil {
+		fields["err"] = err.Error()
+	} else {
+		fields["status"] = resp.Status
+	}
+	log.WithFields(fields).Trace("API request")
+	return resp, err
+}
+
+// acceptRoundTripper - ask for a media type of its own on every request,
+// such as the table rendering of a list
+type acceptRoundTripper struct {
+	accept string
+	next   http.RoundTripper
+}
+
+func (rt *acceptRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// requests are not to be modified by round trippers, send a copy
+	req = req.WithContext(req.Context())
+	req.Header = req.Header.Clone()
+	req.Header.Set("Accept", rt.accept)
+	return rt.next.RoundTrip(req)
+}
+
+// logRequests - wrap the transport so requests are logged, see loggingRoundTripper
+func logRequests(rt http.RoundTripper) http.RoundTripper {
+	return &loggingRoundTripper{next: rt}
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetConfigMaps - a public function for searching configmaps with keyword.
+// With --key or --value-contains only the configmaps with a matching key are
+// kept, and Keys holds the keys that matched.
+func GetConfigMaps(opt *options.SearchOptions, keywords []string) ([]GetConfigMapsResponse, error) {
+	var configMapResponse []GetConfigMapsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	configMapList, err := util.ClientFor(opt).ConfigMapList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, configMap := range configMapList.Items {
+		// return all configmaps under namespace if no keyword specific
+		match, ok := matcher.match(&configMap)
+		if !ok {
+			continue
+		}
+		keys, ok := matchingKeys(opt, configMapData(configMap))
+		if !ok {
+			continue
+		}
+		configMapInfo := GetConfigMapsResponse{
+			ConfigMap:  configMap,
+			StatusLine: match.Highlight(NewConfigMapDetails(configMap)),
+			Match:      match,
+			Keys:       keys,
+		}
+		configMapResponse = append(configMapResponse, configMapInfo)
+	}
+	sortMatches(opt, configMapResponse, func(i int) Match { return configMapResponse[i].Match })
+	return configMapResponse, nil
+}
+
+// NewConfigMapDetails - render a configmap as a table row, counting its
+// text and binary keys like kubectl
+func NewConfigMapDetails(configMap corev1.ConfigMap) string {
+	return fmt.Sprintf(util.ConfigMapRowTemplate,
+		configMap.Namespace,
+		configMap.Name,
+		len(configMap.Data)+len(configMap.BinaryData),
+		util.FormatAge(configMap.CreationTimestamp.Time))
+}
+
+// NewConfigMapKeyDetails - render one table row per key of a configmap, with
+// the size of its value, or the value itself when showValues is set
+func NewConfigMapKeyDetails(configMap corev1.ConfigMap, keys []string, showValues bool) []string {
+	data := configMapData(configMap)
+	var lines []string
+	for _, key := range keys {
+		value := fmt.Sprintf("%d bytes", len(data[key]))
+		if showValues {
+			value = secretValue(data[key])
+		}
+		lines = append(lines, fmt.Sprintf(util.ConfigMapKeyRowTemplate, configMap.Namespace, configMap.Name, key, value))
+	}
+	return lines
+}
+
+// configMapData - the text and binary data of a configmap by key
+func configMapData(configMap corev1.ConfigMap) map[string][]byte {
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		data[key] = value
+	}
+	return data
+}
+
+// matchingKeys - the sorted keys of data that --key and --value-contains
+// match, all of them without either flag. Reports false when the flags are
+// given and no key matches, the object is left out then.
+func matchingKeys(opt *options.SearchOptions, data map[string][]byte) ([]string, bool) {
+	var keys []string
+	for key, value := range data {
+		if opt.DataKey != "" && key != opt.DataKey {
+			continue
+		}
+		if opt.ValueContains != "" && !bytes.Contains(value, []byte(opt.ValueContains)) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, len(keys) > 0 || !opt.FiltersData()
+}
+
+type GetConfigMapsResponse struct {
+	ConfigMap  corev1.ConfigMap
+	StatusLine string
+	Match      Match
+	Keys       []string
+}
@@ -0,0 +1,88 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetDaemonSets - a public function for searching daemonsets with keyword
+func GetDaemonSets(opt *options.SearchOptions, keywords []string) ([]GetDaemonSetsResponse, error) {
+	var daemonSetResponse []GetDaemonSetsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	daemonSetList, err := util.ClientFor(opt).DaemonsetList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, daemonSet := range daemonSetList.Items {
+		if opt.Image != "" && !hasImage(daemonSet.Spec.Template.Spec, opt.Image) {
+			continue
+		}
+		if opt.NotReady && daemonSetReady(daemonSet) {
+			continue
+		}
+		// return all daemonsets under namespace if no keyword specific
+		match, ok := matcher.match(&daemonSet)
+		if !ok {
+			continue
+		}
+		daemonSetInfo := GetDaemonSetsResponse{
+			DaemonSet:  daemonSet,
+			StatusLine: match.Highlight(NewDaemonSetDetails(daemonSet)),
+			Match:      match,
+		}
+		daemonSetResponse = append(daemonSetResponse, daemonSetInfo)
+	}
+	sortMatches(opt, daemonSetResponse, func(i int) Match { return daemonSetResponse[i].Match })
+	return daemonSetResponse, nil
+}
+
+// NewDaemonSetDetails - render a daemonset as a table row
+func NewDaemonSetDetails(daemonSet appsv1.DaemonSet) string {
+	return fmt.Sprintf(util.DaemonsetRowTemplate,
+		daemonSet.Namespace,
+		daemonSet.Name,
+		daemonSet.Status.DesiredNumberScheduled,
+		daemonSet.Status.CurrentNumberScheduled,
+		daemonSet.Status.NumberReady,
+		daemonSet.Status.UpdatedNumberScheduled,
+		daemonSet.Status.NumberAvailable,
+		orNone(util.KeysString(daemonSet.Spec.Template.Spec.NodeSelector)),
+		util.FormatAge(daemonSet.CreationTimestamp.Time))
+}
+
+// NewDaemonSetDetailsWide - render a daemonset as a table row with the `-o wide` columns
+func NewDaemonSetDetailsWide(daemonSet appsv1.DaemonSet) string {
+	return fmt.Sprintf(util.DaemonsetRowTemplateWide,
+		daemonSet.Namespace,
+		daemonSet.Name,
+		daemonSet.Status.DesiredNumberScheduled,
+		daemonSet.Status.CurrentNumberScheduled,
+		daemonSet.Status.NumberReady,
+		daemonSet.Status.UpdatedNumberScheduled,
+		daemonSet.Status.NumberAvailable,
+		orNone(util.KeysString(daemonSet.Spec.Template.Spec.NodeSelector)),
+		util.FormatAge(daemonSet.CreationTimestamp.Time),
+		containerNames(daemonSet.Spec.Template.Spec),
+		ContainerImages(daemonSet.Spec.Template.Spec),
+		metav1.FormatLabelSelector(daemonSet.Spec.Selector))
+}
+
+// daemonSetReady - report whether a daemonset's pod is ready on every node
+// it should run on
+func daemonSetReady(daemonSet appsv1.DaemonSet) bool {
+	return daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled
+}
+
+type GetDaemonSetsResponse struct {
+	DaemonSet  appsv1.DaemonSet
+	StatusLine string
+	Match      Match
+}
@@ -0,0 +1,86 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// redactedValue - what the values of a secret are replaced with for --deep
+const redactedValue = "<redacted>"
+
+// objectLines - the lines of obj serialized as YAML, as kubectl get -o yaml
+// shows it, for --deep to search. managedFields is left out, it repeats
+// every field name of the object. The values of a secret are redacted
+// unless showValues is set, so they are neither matched nor printed.
+func objectLines(obj runtime.Object, showValues bool) []string {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	if metadata, ok := data["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+	}
+	if _, ok := obj.(*corev1.Secret); ok && !showValues {
+		redactSecret(data)
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return lines
+}
+
+// redactSecret - replace the values of the serialized secret data, keeping
+// the keys, and drop the copy kubectl apply keeps of them in an annotation
+func redactSecret(data map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		if values, ok := data[field].(map[string]interface{}); ok {
+			for key := range values {
+				values[key] = redactedValue
+			}
+		}
+	}
+	if metadata, ok := data["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, corev1.LastAppliedConfigAnnotation)
+		}
+	}
+}
+
+// fromObjectLines - report whether a hit in field came from the serialized
+// object rather than from the searched fields
+func fromObjectLines(field string, searched []string) bool {
+	for _, s := range searched {
+		if s == field {
+			return false
+		}
+	}
+	return true
+}
+
+// DeepMatchLine - with --deep, the first line of the serialized obj a
+// keyword was found in, highlighted, or "" when it matched on its name
+func DeepMatchLine(opt *options.SearchOptions, keywords []string, obj runtime.Object) string {
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return ""
+	}
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		return ""
+	}
+	match, ok := matcher.match(metaObj)
+	if !ok || match.Line == "" {
+		return ""
+	}
+	return match.Highlight(match.Line)
+}
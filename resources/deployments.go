@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetDeployments - a public function for searching deployments with keyword
+func GetDeployments(opt *options.SearchOptions, keywords []string) ([]GetDeploymentsResponse, error) {
+	var deploymentResponse []GetDeploymentsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	deploymentList, err := util.ClientFor(opt).DeploymentList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deployment := range deploymentList.Items {
+		if opt.Image != "" && !hasImage(deployment.Spec.Template.Spec, opt.Image) {
+			continue
+		}
+		// return all deployments under namespace if no keyword specific
+		match, ok := matcher.match(&deployment)
+		if !ok {
+			continue
+		}
+		deploymentInfo := GetDeploymentsResponse{
+			Deployment: deployment,
+			StatusLine: match.Highlight(NewDeploymentDetails(deployment)),
+			Match:      match,
+		}
+		deploymentResponse = append(deploymentResponse, deploymentInfo)
+	}
+	sortMatches(opt, deploymentResponse, func(i int) Match { return deploymentResponse[i].Match })
+	return deploymentResponse, nil
+}
+
+// NewDeploymentDetails - render a deployment as a table row
+func NewDeploymentDetails(deployment appsv1.Deployment) string {
+	return fmt.Sprintf(util.DeploymentRowTemplate,
+		deployment.Namespace,
+		deployment.Name,
+		desiredReplicas(deployment),
+		deployment.Status.Replicas,
+		deployment.Status.UpdatedReplicas,
+		deployment.Status.AvailableReplicas,
+		util.FormatAge(deployment.CreationTimestamp.Time))
+}
+
+// NewDeploymentDetailsWide - render a deployment as a table row with the `-o wide` columns
+func NewDeploymentDetailsWide(deployment appsv1.Deployment) string {
+	return fmt.Sprintf(util.DeploymentRowTemplateWide,
+		deployment.Namespace,
+		deployment.Name,
+		desiredReplicas(deployment),
+		deployment.Status.Replicas,
+		deployment.Status.UpdatedReplicas,
+		deployment.Status.AvailableReplicas,
+		util.FormatAge(deployment.CreationTimestamp.Time),
+		containerNames(deployment.Spec.Template.Spec),
+		ContainerImages(deployment.Spec.Template.Spec),
+		metav1.FormatLabelSelector(deployment.Spec.Selector))
+}
+
+// desiredReplicas - spec.replicas, which the API server defaults to 1
+func desiredReplicas(deployment appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas != nil {
+		return *deployment.Spec.Replicas
+	}
+	return 1
+}
+
+type GetDeploymentsResponse struct {
+	Deployment appsv1.Deployment
+	StatusLine string
+	Match      Match
+}
@@ -0,0 +1,172 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// the changes of an object between the namespace searched and --against
+const (
+	DiffAdded     = "added"     // only in the namespace searched
+	DiffRemoved   = "removed"   // only in the --against namespace
+	DiffChanged   = "changed"   // in both, with different data
+	DiffUnchanged = "unchanged" // in both, with the same data
+)
+
+// DiffConfigMaps - compare the configmaps matching keywords in the namespace
+// of opt with the ones in against, by name and data
+func DiffConfigMaps(opt *options.SearchOptions, against string, keywords []string) ([]GetDiffResponse, error) {
+	var sides [2][]diffObject
+	for i, o := range []*options.SearchOptions{opt, inNamespace(opt, against)} {
+		configMaps, err := GetConfigMaps(o, keywords)
+		if err != nil {
+			return nil, err
+		}
+		for j := range configMaps {
+			sides[i] = append(sides[i], diffObject{&configMaps[j].ConfigMap, configMaps[j].Match, configMapData(configMaps[j].ConfigMap)})
+		}
+	}
+	return diffObjects(sides[0], sides[1]), nil
+}
+
+// DiffSecrets - compare the secrets matching keywords in the namespace of opt
+// with the ones in against, by name and data. Only keys are reported, never
+// the values.
+func DiffSecrets(opt *options.SearchOptions, against string, keywords []string) ([]GetDiffResponse, error) {
+	var sides [2][]diffObject
+	for i, o := range []*options.SearchOptions{opt, inNamespace(opt, against)} {
+		secrets, err := GetSecrets(o, keywords)
+		if err != nil {
+			return nil, err
+		}
+		for j := range secrets {
+			sides[i] = append(sides[i], diffObject{&secrets[j].Secret, secrets[j].Match, secrets[j].Secret.Data})
+		}
+	}
+	return diffObjects(sides[0], sides[1]), nil
+}
+
+// inNamespace - a copy of opt searching namespace ns only
+func inNamespace(opt *options.SearchOptions, ns string) *options.SearchOptions {
+	o := *opt
+	o.Namespaces = []string{ns}
+	o.AllNamespaces = false
+	return &o
+}
+
+// diffObject - an object to compare, with the data compared
+type diffObject struct {
+	object runtime.Object
+	match  Match
+	data   map[string][]byte
+}
+
+// diffObjects - pair the objects of both namespaces up by name and compare
+// their data, sorted by name. An added or removed object has all its keys
+// added or removed.
+func diffObjects(base, against []diffObject) []GetDiffResponse {
+	againstByName := make(map[string]diffObject, len(against))
+	for _, obj := range against {
+		againstByName[obj.match.Name] = obj
+	}
+
+	var diffResponse []GetDiffResponse
+	seen := map[string]bool{}
+	for _, obj := range base {
+		seen[obj.match.Name] = true
+		other, ok := againstByName[obj.match.Name]
+		if !ok {
+			diffResponse = append(diffResponse, newDiffResponse(obj, DiffAdded, dataChanges(obj.data, nil)))
+			continue
+		}
+		change, keys := DiffUnchanged, dataChanges(obj.data, other.data)
+		if len(keys) > 0 {
+			change = DiffChanged
+		}
+		diffResponse = append(diffResponse, newDiffResponse(obj, change, keys))
+	}
+	for _, obj := range against {
+		if !seen[obj.match.Name] {
+			diffResponse = append(diffResponse, newDiffResponse(obj, DiffRemoved, dataChanges(nil, obj.data)))
+		}
+	}
+	sort.SliceStable(diffResponse, func(i, j int) bool {
+		return diffResponse[i].Match.Name < diffResponse[j].Match.Name
+	})
+	return diffResponse
+}
+
+// dataChanges - the sorted keys that differ between data and against, marked
+// + when only data has them, - when only against does and ~ when the values
+// differ
+func dataChanges(data, against map[string][]byte) []string {
+	keys := map[string]bool{}
+	for key := range data {
+		keys[key] = true
+	}
+	for key := range against {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, key := range sorted {
+		value, inData := data[key]
+		other, inAgainst := against[key]
+		switch {
+		case !inAgainst:
+			changes = append(changes, "+"+key)
+		case !inData:
+			changes = append(changes, "-"+key)
+		case !bytes.Equal(value, other):
+			changes = append(changes, "~"+key)
+		}
+	}
+	return changes
+}
+
+func newDiffResponse(obj diffObject, change string, keys []string) GetDiffResponse {
+	return GetDiffResponse{
+		Object:     obj.object,
+		Change:     change,
+		Keys:       keys,
+		StatusLine: obj.match.Highlight(NewDiffDetails(obj.match.Name, change, keys)),
+		Match:      obj.match,
+	}
+}
+
+// NewDiffDetails - render the change of an object as a table row, colored
+// like a diff
+func NewDiffDetails(name string, change string, keys []string) string {
+	changed := strings.Join(keys, ",")
+	if changed == "" {
+		changed = "<none>"
+	}
+	switch change {
+	case DiffAdded:
+		change = util.Added(change)
+	case DiffRemoved:
+		change = util.Deleted(change)
+	case DiffChanged:
+		change = util.Changed(change)
+	}
+	return fmt.Sprintf(util.DiffRowTemplate, name, change, changed)
+}
+
+type GetDiffResponse struct {
+	Object     runtime.Object
+	Change     string
+	Keys       []string
+	StatusLine string
+	Match      Match
+}
@@ -0,0 +1,193 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GetResources - a public function for searching any resource by name through
+// the dynamic client, for custom resources and anything else without its own
+// search
+func GetResources(opt *options.SearchOptions, keywords []string, gvr schema.GroupVersionResource, namespaced bool) ([]GetResourcesResponse, error) {
+	var resourceResponse []GetResourcesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	resourceList, err := util.ClientFor(opt).DynamicList(opt, gvr, namespaced)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range resourceList.Items {
+		// return all objects under namespace if no keyword specific
+		match, ok := matcher.match(&obj)
+		if !ok {
+			continue
+		}
+		resourceInfo := GetResourcesResponse{
+			Object:     obj,
+			StatusLine: match.Highlight(NewResourceDetails(obj, namespaced)),
+			Match:      match,
+		}
+		resourceResponse = append(resourceResponse, resourceInfo)
+	}
+	sortMatches(opt, resourceResponse, func(i int) Match { return resourceResponse[i].Match })
+	return resourceResponse, nil
+}
+
+// NewResourceDetails - render any object as a table row of its name and age
+func NewResourceDetails(obj unstructured.Unstructured, namespaced bool) string {
+	age := util.FormatAge(obj.GetCreationTimestamp().Time)
+	if !namespaced {
+		return fmt.Sprintf(util.ClusterResourceRowTemplate, obj.GetName(), age)
+	}
+	return fmt.Sprintf(util.ResourceRowTemplate, obj.GetNamespace(), obj.GetName(), age)
+}
+
+// GetCustomResourceDefinitions - a public function for searching custom
+// resource definitions with keyword, matching on the name, group or kind
+func GetCustomResourceDefinitions(opt *options.SearchOptions, keywords []string) ([]GetResourcesResponse, error) {
+	var crdResponse []GetResourcesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	crdList, err := util.ClientFor(opt).CustomResourceDefinitionList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, crd := range crdList.Items {
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+
+		// return all definitions if no keyword specific
+		match, ok := matcher.match(&crd, group, kind)
+		if !ok {
+			continue
+		}
+		crdInfo := GetResourcesResponse{
+			Object:     crd,
+			StatusLine: match.Highlight(NewCustomResourceDefinitionDetails(crd)),
+			Match:      match,
+		}
+		crdResponse = append(crdResponse, crdInfo)
+	}
+	sortMatches(opt, crdResponse, func(i int) Match { return crdResponse[i].Match })
+	return crdResponse, nil
+}
+
+// NewCustomResourceDefinitionDetails - render a custom resource definition as a table row
+func NewCustomResourceDefinitionDetails(crd unstructured.Unstructured) string {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	scope, _, _ := unstructured.NestedString(crd.Object, "spec", "scope")
+
+	return fmt.Sprintf(util.CrdRowTemplate,
+		crd.GetName(),
+		group,
+		kind,
+		scope,
+		orNone(crdVersions(crd)),
+		util.FormatAge(crd.GetCreationTimestamp().Time))
+}
+
+// crdVersions - the served versions of a definition; v1beta1 definitions may
+// only set the single spec.version
+func crdVersions(crd unstructured.Unstructured) string {
+	var served []string
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(version, "name")
+		if isServed, found, _ := unstructured.NestedBool(version, "served"); found && !isServed {
+			continue
+		}
+		served = append(served, name)
+	}
+	if len(served) == 0 {
+		if version, _, _ := unstructured.NestedString(crd.Object, "spec", "version"); version != "" {
+			served = append(served, version)
+		}
+	}
+	return strings.Join(served, ",")
+}
+
+type GetResourcesResponse struct {
+	Object     unstructured.Unstructured
+	StatusLine string
+	Match      Match
+}
+
+// GetServerTable - a public function for searching any resource by name like
+// GetResources, with the rows printed by the API server: the columns kubectl
+// shows for the resource, and with wide the lower priority ones as well
+func GetServerTable(opt *options.SearchOptions, keywords []string, gvr schema.GroupVersionResource, namespaced bool, wide bool) (string, []GetResourcesResponse, error) {
+	var resourceResponse []GetResourcesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return "", nil, err
+	}
+	table, err := util.ClientFor(opt).ServerTableList(opt, gvr, namespaced)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var columns []int
+	var header []string
+	if namespaced {
+		header = append(header, "NAMESPACE")
+	}
+	for i, column := range table.ColumnDefinitions {
+		if column.Priority == 0 || wide {
+			columns = append(columns, i)
+			header = append(header, strings.ToUpper(column.Name))
+		}
+	}
+
+	for _, row := range table.Rows {
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(row.Object.Raw); err != nil {
+			return "", nil, fmt.Errorf("the server sent a table row without its object: %v", err)
+		}
+		match, ok := matcher.match(&obj)
+		if !ok {
+			continue
+		}
+		resourceInfo := GetResourcesResponse{
+			Object:     obj,
+			StatusLine: match.Highlight(NewServerRowDetails(obj, row, columns, namespaced)),
+			Match:      match,
+		}
+		resourceResponse = append(resourceResponse, resourceInfo)
+	}
+	sortMatches(opt, resourceResponse, func(i int) Match { return resourceResponse[i].Match })
+	return strings.Join(header, "\t"), resourceResponse, nil
+}
+
+// NewServerRowDetails - render the cells of a server printed row in columns
+// as a table row, missing cells as <none> like kubectl
+func NewServerRowDetails(obj unstructured.Unstructured, row metav1.TableRow, columns []int, namespaced bool) string {
+	var cells []string
+	if namespaced {
+		cells = append(cells, obj.GetNamespace())
+	}
+	for _, i := range columns {
+		if i >= len(row.Cells) || row.Cells[i] == nil {
+			cells = append(cells, "<none>")
+			continue
+		}
+		cells = append(cells, fmt.Sprint(row.Cells[i]))
+	}
+	return strings.Join(cells, "\t")
+}
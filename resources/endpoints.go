@@ -0,0 +1,168 @@
+package resources
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1alpha1 "k8s.io/api/discovery/v1alpha1"
+)
+
+// serviceNameLabel - the label linking an EndpointSlice to its service
+const serviceNameLabel = "kubernetes.io/service-name"
+
+// maxAddresses - how many addresses a table cell lists before summarizing
+const maxAddresses = 3
+
+// GetEndpoints - a public function for searching endpoints by keyword; an
+// Endpoints object has the name of its service
+func GetEndpoints(opt *options.SearchOptions, keywords []string) ([]GetEndpointsResponse, error) {
+	var endpointsResponse []GetEndpointsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	endpointsList, err := util.ClientFor(opt).EndpointsList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, endpoints := range endpointsList.Items {
+		// return all endpoints under namespace if no keyword specific
+		match, ok := matcher.match(&endpoints)
+		if !ok {
+			continue
+		}
+		endpointsInfo := GetEndpointsResponse{
+			Endpoints:  endpoints,
+			StatusLine: match.Highlight(NewEndpointsDetails(endpoints)),
+			Match:      match,
+		}
+		endpointsResponse = append(endpointsResponse, endpointsInfo)
+	}
+	sortMatches(opt, endpointsResponse, func(i int) Match { return endpointsResponse[i].Match })
+	return endpointsResponse, nil
+}
+
+// NewEndpointsDetails - render endpoints as a table row
+func NewEndpointsDetails(endpoints corev1.Endpoints) string {
+	ready, notReady := EndpointAddresses(endpoints)
+
+	return fmt.Sprintf(util.EndpointsRowTemplate,
+		endpoints.Namespace,
+		endpoints.Name,
+		summarizeAddresses(ready),
+		summarizeAddresses(notReady),
+		util.FormatAge(endpoints.CreationTimestamp.Time))
+}
+
+// EndpointAddresses - the ready and not ready host:port pairs of endpoints
+func EndpointAddresses(endpoints corev1.Endpoints) (ready []string, notReady []string) {
+	for _, subset := range endpoints.Subsets {
+		ready = append(ready, subsetAddresses(subset.Addresses, subset.Ports)...)
+		notReady = append(notReady, subsetAddresses(subset.NotReadyAddresses, subset.Ports)...)
+	}
+	return ready, notReady
+}
+
+func subsetAddresses(addresses []corev1.EndpointAddress, ports []corev1.EndpointPort) []string {
+	var pairs []string
+	for _, address := range addresses {
+		if len(ports) == 0 {
+			pairs = append(pairs, address.IP)
+			continue
+		}
+		for _, port := range ports {
+			pairs = append(pairs, net.JoinHostPort(address.IP, strconv.Itoa(int(port.Port))))
+		}
+	}
+	return pairs
+}
+
+// summarizeAddresses - list the first few addresses like kubectl does
+func summarizeAddresses(addresses []string) string {
+	if len(addresses) > maxAddresses {
+		return fmt.Sprintf("%s + %d more...", strings.Join(addresses[:maxAddresses], ","), len(addresses)-maxAddresses)
+	}
+	return orNone(strings.Join(addresses, ","))
+}
+
+// GetEndpointSlices - a public function for searching endpoint slices with
+// keyword, matching on the slice name or the name of its service
+func GetEndpointSlices(opt *options.SearchOptions, keywords []string) ([]GetEndpointSlicesResponse, error) {
+	var sliceResponse []GetEndpointSlicesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	sliceList, err := util.ClientFor(opt).EndpointSliceList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slice := range sliceList.Items {
+		// return all slices under namespace if no keyword specific
+		match, ok := matcher.match(&slice, slice.Labels[serviceNameLabel])
+		if !ok {
+			continue
+		}
+		sliceInfo := GetEndpointSlicesResponse{
+			EndpointSlice: slice,
+			StatusLine:    match.Highlight(NewEndpointSliceDetails(slice)),
+			Match:         match,
+		}
+		sliceResponse = append(sliceResponse, sliceInfo)
+	}
+	sortMatches(opt, sliceResponse, func(i int) Match { return sliceResponse[i].Match })
+	return sliceResponse, nil
+}
+
+// NewEndpointSliceDetails - render an endpoint slice as a table row
+func NewEndpointSliceDetails(slice discoveryv1alpha1.EndpointSlice) string {
+	addressType := ""
+	if slice.AddressType != nil {
+		addressType = string(*slice.AddressType)
+	}
+	var ports []string
+	for _, port := range slice.Ports {
+		if port.Port != nil {
+			ports = append(ports, strconv.Itoa(int(*port.Port)))
+		}
+	}
+	var addresses []string
+	var ready int
+	for _, endpoint := range slice.Endpoints {
+		addresses = append(addresses, endpoint.Addresses...)
+		// a nil condition means unknown, which consumers treat as ready
+		if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+			ready++
+		}
+	}
+
+	return fmt.Sprintf(util.EndpointSliceRowTemplate,
+		slice.Namespace,
+		slice.Name,
+		orNone(slice.Labels[serviceNameLabel]),
+		orNone(addressType),
+		orNone(strings.Join(ports, ",")),
+		ready,
+		len(slice.Endpoints),
+		summarizeAddresses(addresses),
+		util.FormatAge(slice.CreationTimestamp.Time))
+}
+
+type GetEndpointsResponse struct {
+	Endpoints  corev1.Endpoints
+	StatusLine string
+	Match      Match
+}
+
+type GetEndpointSlicesResponse struct {
+	EndpointSlice discoveryv1alpha1.EndpointSlice
+	StatusLine    string
+	Match         Match
+}
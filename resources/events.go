@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetEvents - a public function for searching events by the name of the object
+// they involve. kind and eventType optionally narrow the search to one kind of
+// involved object and to Normal or Warning events. Unless --sort-by is given,
+// the most recently seen events come first.
+func GetEvents(opt *options.SearchOptions, keywords []string, kind string, eventType string) ([]GetEventsResponse, error) {
+	var eventResponse []GetEventsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	since, err := sinceCutoff(opt)
+	if err != nil {
+		return nil, err
+	}
+	eventList, err := util.ClientFor(opt).EventList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range eventList.Items {
+		if !since.IsZero() && EventLastSeen(event).Before(since) {
+			continue
+		}
+		if len(kind) > 0 && !strings.EqualFold(event.InvolvedObject.Kind, kind) {
+			continue
+		}
+		if len(eventType) > 0 && !strings.EqualFold(event.Type, eventType) {
+			continue
+		}
+		// return all events under namespace if no keyword specific
+		match, ok := matcher.match(&event, event.InvolvedObject.Name)
+		if !ok {
+			continue
+		}
+		eventInfo := GetEventsResponse{
+			Event:      event,
+			StatusLine: match.Highlight(NewEventDetails(event)),
+			Match:      match,
+		}
+		eventResponse = append(eventResponse, eventInfo)
+	}
+
+	if opt.SortBy == "" && !opt.Fuzzy {
+		sort.SliceStable(eventResponse, func(i, j int) bool {
+			a, b := EventLastSeen(eventResponse[i].Event), EventLastSeen(eventResponse[j].Event)
+			if !a.Equal(b) {
+				return a.After(b)
+			}
+			return eventResponse[i].Match.before(eventResponse[j].Match, "namespace")
+		})
+	} else {
+		sortMatches(opt, eventResponse, func(i int) Match { return eventResponse[i].Match })
+	}
+	return eventResponse, nil
+}
+
+// NewEventDetails - render an event as a table row
+func NewEventDetails(event corev1.Event) string {
+	count := event.Count
+	if count == 0 {
+		count = 1
+	}
+
+	return fmt.Sprintf(util.EventRowTemplate,
+		event.Namespace,
+		util.FormatAge(EventLastSeen(event)),
+		event.Type,
+		event.Reason,
+		fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+		count,
+		strings.TrimSpace(event.Message))
+}
+
+// EventLastSeen - when an event last happened, falling back to the newer
+// eventTime and finally to the creation time for events that don't set it
+func EventLastSeen(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+type GetEventsResponse struct {
+	Event      corev1.Event
+	StatusLine string
+	Match      Match
+}
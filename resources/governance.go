@@ -0,0 +1,205 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+)
+
+// GetPodDisruptionBudgets - a public function for searching pod disruption budgets with keyword
+func GetPodDisruptionBudgets(opt *options.SearchOptions, keywords []string) ([]GetPodDisruptionBudgetsResponse, error) {
+	var pdbResponse []GetPodDisruptionBudgetsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	pdbList, err := util.ClientFor(opt).PodDisruptionBudgetList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pdb := range pdbList.Items {
+		// return all budgets under namespace if no keyword specific
+		match, ok := matcher.match(&pdb)
+		if !ok {
+			continue
+		}
+		pdbInfo := GetPodDisruptionBudgetsResponse{
+			PodDisruptionBudget: pdb,
+			StatusLine:          match.Highlight(NewPodDisruptionBudgetDetails(pdb)),
+			Match:               match,
+		}
+		pdbResponse = append(pdbResponse, pdbInfo)
+	}
+	sortMatches(opt, pdbResponse, func(i int) Match { return pdbResponse[i].Match })
+	return pdbResponse, nil
+}
+
+// NewPodDisruptionBudgetDetails - render a pod disruption budget as a table row
+func NewPodDisruptionBudgetDetails(pdb policyv1beta1.PodDisruptionBudget) string {
+	minAvailable, maxUnavailable := "N/A", "N/A"
+	if pdb.Spec.MinAvailable != nil {
+		minAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		maxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+
+	return fmt.Sprintf(util.PdbRowTemplate,
+		pdb.Namespace,
+		pdb.Name,
+		minAvailable,
+		maxUnavailable,
+		pdb.Status.PodDisruptionsAllowed,
+		pdb.Status.CurrentHealthy,
+		pdb.Status.DesiredHealthy,
+		util.FormatAge(pdb.CreationTimestamp.Time))
+}
+
+// GetResourceQuotas - a public function for searching resource quotas with keyword
+func GetResourceQuotas(opt *options.SearchOptions, keywords []string) ([]GetResourceQuotasResponse, error) {
+	var quotaResponse []GetResourceQuotasResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	quotaList, err := util.ClientFor(opt).ResourceQuotaList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, quota := range quotaList.Items {
+		// return all quotas under namespace if no keyword specific
+		match, ok := matcher.match(&quota)
+		if !ok {
+			continue
+		}
+		quotaInfo := GetResourceQuotasResponse{
+			ResourceQuota: quota,
+			StatusLine:    match.Highlight(NewResourceQuotaDetails(quota)),
+			Match:         match,
+		}
+		quotaResponse = append(quotaResponse, quotaInfo)
+	}
+	sortMatches(opt, quotaResponse, func(i int) Match { return quotaResponse[i].Match })
+	return quotaResponse, nil
+}
+
+// NewResourceQuotaDetails - render a resource quota as a table row, listing
+// used/hard for every resource it limits
+func NewResourceQuotaDetails(quota corev1.ResourceQuota) string {
+	var names []string
+	for name := range quota.Status.Hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var usage []string
+	for _, name := range names {
+		hard := quota.Status.Hard[corev1.ResourceName(name)]
+		used := quota.Status.Used[corev1.ResourceName(name)]
+		usage = append(usage, fmt.Sprintf("%s: %s/%s", name, used.String(), hard.String()))
+	}
+
+	return fmt.Sprintf(util.QuotaRowTemplate,
+		quota.Namespace,
+		quota.Name,
+		orNone(strings.Join(usage, ", ")),
+		util.FormatAge(quota.CreationTimestamp.Time))
+}
+
+// GetLimitRanges - a public function for searching limit ranges with keyword
+func GetLimitRanges(opt *options.SearchOptions, keywords []string) ([]GetLimitRangesResponse, error) {
+	var limitRangeResponse []GetLimitRangesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	limitRangeList, err := util.ClientFor(opt).LimitRangeList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, limitRange := range limitRangeList.Items {
+		// return all limit ranges under namespace if no keyword specific
+		match, ok := matcher.match(&limitRange)
+		if !ok {
+			continue
+		}
+		limitRangeInfo := GetLimitRangesResponse{
+			LimitRange: limitRange,
+			StatusLine: match.Highlight(NewLimitRangeDetails(limitRange)),
+			Match:      match,
+		}
+		limitRangeResponse = append(limitRangeResponse, limitRangeInfo)
+	}
+	sortMatches(opt, limitRangeResponse, func(i int) Match { return limitRangeResponse[i].Match })
+	return limitRangeResponse, nil
+}
+
+// NewLimitRangeDetails - render a limit range as a table row, with the
+// defaults and bounds of every type it limits, e.g. Container: cpu=100m
+func NewLimitRangeDetails(limitRange corev1.LimitRange) string {
+	var types []string
+	for _, limit := range limitRange.Spec.Limits {
+		types = append(types, string(limit.Type))
+	}
+
+	return fmt.Sprintf(util.LimitRangeRowTemplate,
+		limitRange.Namespace,
+		limitRange.Name,
+		orNone(strings.Join(types, ",")),
+		limitValues(limitRange, func(l corev1.LimitRangeItem) corev1.ResourceList { return l.DefaultRequest }),
+		limitValues(limitRange, func(l corev1.LimitRangeItem) corev1.ResourceList { return l.Default }),
+		limitValues(limitRange, func(l corev1.LimitRangeItem) corev1.ResourceList { return l.Min }),
+		limitValues(limitRange, func(l corev1.LimitRangeItem) corev1.ResourceList { return l.Max }),
+		util.FormatAge(limitRange.CreationTimestamp.Time))
+}
+
+// limitValues - the values field picks out of every limit of limitRange,
+// grouped by the type they apply to
+func limitValues(limitRange corev1.LimitRange, field func(corev1.LimitRangeItem) corev1.ResourceList) string {
+	var groups []string
+	for _, limit := range limitRange.Spec.Limits {
+		values := field(limit)
+		var names []string
+		for name := range values {
+			names = append(names, string(name))
+		}
+		if len(names) == 0 {
+			continue
+		}
+		sort.Strings(names)
+
+		var pairs []string
+		for _, name := range names {
+			value := values[corev1.ResourceName(name)]
+			pairs = append(pairs, fmt.Sprintf("%s=%s", name, value.String()))
+		}
+		groups = append(groups, fmt.Sprintf("%s: %s", limit.Type, strings.Join(pairs, ",")))
+	}
+	return orNone(strings.Join(groups, "; "))
+}
+
+type GetPodDisruptionBudgetsResponse struct {
+	PodDisruptionBudget policyv1beta1.PodDisruptionBudget
+	StatusLine          string
+	Match               Match
+}
+
+type GetResourceQuotasResponse struct {
+	ResourceQuota corev1.ResourceQuota
+	StatusLine    string
+	Match         Match
+}
+
+type GetLimitRangesResponse struct {
+	LimitRange corev1.LimitRange
+	StatusLine string
+	Match      Match
+}
@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+)
+
+// GetHPAs - a public function for searching horizontal pod autoscalers with
+// keyword, matching on the HPA name or the name of the object it scales
+func GetHPAs(opt *options.SearchOptions, keywords []string) ([]GetHPAsResponse, error) {
+	var hpaResponse []GetHPAsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	hpaList, err := util.ClientFor(opt).HorizontalPodAutoscalerList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hpa := range hpaList.Items {
+		// return all hpas under namespace if no keyword specific
+		match, ok := matcher.match(&hpa, hpa.Spec.ScaleTargetRef.Name)
+		if !ok {
+			continue
+		}
+		hpaInfo := GetHPAsResponse{
+			HPA:        hpa,
+			StatusLine: match.Highlight(NewHPADetails(hpa)),
+			Match:      match,
+		}
+		hpaResponse = append(hpaResponse, hpaInfo)
+	}
+	sortMatches(opt, hpaResponse, func(i int) Match { return hpaResponse[i].Match })
+	return hpaResponse, nil
+}
+
+// NewHPADetails - render an HPA as a table row
+func NewHPADetails(hpa autoscalingv2beta2.HorizontalPodAutoscaler) string {
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	return fmt.Sprintf(util.HpaRowTemplate,
+		hpa.Namespace,
+		hpa.Name,
+		fmt.Sprintf("%s/%s", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name),
+		hpaTargets(hpa),
+		minReplicas,
+		hpa.Spec.MaxReplicas,
+		hpa.Status.CurrentReplicas,
+		util.FormatAge(hpa.CreationTimestamp.Time))
+}
+
+// hpaTargets - list each metric as current/target, the way kubectl does. The
+// status reports current metrics in the same order as the spec declares them.
+func hpaTargets(hpa autoscalingv2beta2.HorizontalPodAutoscaler) string {
+	var targets []string
+	for i, spec := range hpa.Spec.Metrics {
+		var current *autoscalingv2beta2.MetricStatus
+		if i < len(hpa.Status.CurrentMetrics) && hpa.Status.CurrentMetrics[i].Type == spec.Type {
+			current = &hpa.Status.CurrentMetrics[i]
+		}
+		targets = append(targets, fmt.Sprintf("%s/%s", metricCurrent(current), metricTarget(spec)))
+	}
+	if len(targets) == 0 {
+		return "<none>"
+	}
+	return strings.Join(targets, ", ")
+}
+
+func metricTarget(spec autoscalingv2beta2.MetricSpec) string {
+	switch spec.Type {
+	case autoscalingv2beta2.ResourceMetricSourceType:
+		if spec.Resource != nil {
+			return metricTargetValue(spec.Resource.Target)
+		}
+	case autoscalingv2beta2.PodsMetricSourceType:
+		if spec.Pods != nil {
+			return metricTargetValue(spec.Pods.Target)
+		}
+	case autoscalingv2beta2.ObjectMetricSourceType:
+		if spec.Object != nil {
+			return metricTargetValue(spec.Object.Target)
+		}
+	case autoscalingv2beta2.ExternalMetricSourceType:
+		if spec.External != nil {
+			return metricTargetValue(spec.External.Target)
+		}
+	}
+	return "<unknown>"
+}
+
+func metricTargetValue(target autoscalingv2beta2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return "<unknown>"
+}
+
+func metricCurrent(status *autoscalingv2beta2.MetricStatus) string {
+	if status == nil {
+		return "<unknown>"
+	}
+	var current autoscalingv2beta2.MetricValueStatus
+	switch {
+	case status.Resource != nil:
+		current = status.Resource.Current
+	case status.Pods != nil:
+		current = status.Pods.Current
+	case status.Object != nil:
+		current = status.Object.Current
+	case status.External != nil:
+		current = status.External.Current
+	default:
+		return "<unknown>"
+	}
+	switch {
+	case current.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *current.AverageUtilization)
+	case current.AverageValue != nil:
+		return current.AverageValue.String()
+	case current.Value != nil:
+		return current.Value.String()
+	}
+	return "<unknown>"
+}
+
+type GetHPAsResponse struct {
+	HPA        autoscalingv2beta2.HorizontalPodAutoscaler
+	StatusLine string
+	Match      Match
+}
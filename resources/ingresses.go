@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+)
+
+// GetIngresses - a public function for searching ingresses with keyword,
+// matching on the ingress name, its hosts and its backend service names
+func GetIngresses(opt *options.SearchOptions, keywords []string) ([]GetIngressesResponse, error) {
+	var ingressResponse []GetIngressesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	ingressList, err := util.ClientFor(opt).IngressList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ingress := range ingressList.Items {
+		hosts := ingressHosts(ingress)
+		backends := ingressBackends(ingress)
+
+		// return all ingresses under namespace if no keyword specific
+		match, ok := matcher.match(&ingress, append(hosts, backends...)...)
+		if !ok {
+			continue
+		}
+		ingressInfo := GetIngressesResponse{
+			Ingress:    ingress,
+			StatusLine: match.Highlight(NewIngressDetails(ingress, hosts, backends)),
+			Match:      match,
+		}
+		ingressResponse = append(ingressResponse, ingressInfo)
+	}
+	sortMatches(opt, ingressResponse, func(i int) Match { return ingressResponse[i].Match })
+	return ingressResponse, nil
+}
+
+// NewIngressDetails - render an ingress as a table row
+func NewIngressDetails(ingress networkingv1beta1.Ingress, hosts []string, backends []string) string {
+	var addresses []string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addresses = append(addresses, lb.IP)
+		} else if lb.Hostname != "" {
+			addresses = append(addresses, lb.Hostname)
+		}
+	}
+
+	ports := "80"
+	if len(ingress.Spec.TLS) > 0 {
+		ports = "80, 443"
+	}
+
+	return fmt.Sprintf(util.IngressRowTemplate,
+		ingress.Namespace,
+		ingress.Name,
+		orNone(strings.Join(hosts, ",")),
+		orNone(strings.Join(backends, ",")),
+		strings.Join(addresses, ","),
+		ports,
+		util.FormatAge(ingress.CreationTimestamp.Time))
+}
+
+func ingressHosts(ingress networkingv1beta1.Ingress) []string {
+	var hosts []string
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	if len(hosts) == 0 && len(ingress.Spec.Rules) > 0 {
+		hosts = append(hosts, "*")
+	}
+	return hosts
+}
+
+func ingressBackends(ingress networkingv1beta1.Ingress) []string {
+	seen := map[string]bool{}
+	var backends []string
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			backends = append(backends, name)
+		}
+	}
+	if ingress.Spec.Backend != nil {
+		add(ingress.Spec.Backend.ServiceName)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			add(path.Backend.ServiceName)
+		}
+	}
+	return backends
+}
+
+type GetIngressesResponse struct {
+	Ingress    networkingv1beta1.Ingress
+	StatusLine string
+	Match      Match
+}
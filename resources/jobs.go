@@ -0,0 +1,146 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetJobs - a public function for searching jobs with keyword
+func GetJobs(opt *options.SearchOptions, keywords []string) ([]GetJobsResponse, error) {
+	var jobResponse []GetJobsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	jobList, err := util.ClientFor(opt).JobList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobList.Items {
+		// return all jobs under namespace if no keyword specific
+		match, ok := matcher.match(&job)
+		if !ok {
+			continue
+		}
+		jobInfo := GetJobsResponse{
+			Job:        job,
+			StatusLine: match.Highlight(NewJobDetails(job)),
+			Match:      match,
+		}
+		jobResponse = append(jobResponse, jobInfo)
+	}
+	sortMatches(opt, jobResponse, func(i int) Match { return jobResponse[i].Match })
+	return jobResponse, nil
+}
+
+// NewJobDetails - render a job as a table row
+func NewJobDetails(job batchv1.Job) string {
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+
+	var duration string
+	if job.Status.StartTime != nil {
+		end := time.Now()
+		if job.Status.CompletionTime != nil {
+			end = job.Status.CompletionTime.Time
+		}
+		duration = util.GetAge(end.Sub(job.Status.StartTime.Time))
+	}
+
+	return fmt.Sprintf(util.JobRowTemplate,
+		job.Namespace,
+		job.Name,
+		job.Status.Succeeded,
+		completions,
+		JobStatus(job),
+		orNone(duration),
+		util.FormatAge(job.CreationTimestamp.Time))
+}
+
+// JobStatus - return whether a job has completed, failed or is still running
+func JobStatus(job batchv1.Job) string {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed"
+		}
+	}
+	if job.Status.Active > 0 {
+		return "Running"
+	}
+	return "Pending"
+}
+
+type GetJobsResponse struct {
+	Job        batchv1.Job
+	StatusLine string
+	Match      Match
+}
+
+// GetCronJobs - a public function for searching cronjobs with keyword
+func GetCronJobs(opt *options.SearchOptions, keywords []string) ([]GetCronJobsResponse, error) {
+	var cronJobResponse []GetCronJobsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	cronJobList, err := util.ClientFor(opt).CronJobList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cronJob := range cronJobList.Items {
+		// return all cronjobs under namespace if no keyword specific
+		match, ok := matcher.match(&cronJob)
+		if !ok {
+			continue
+		}
+		cronJobInfo := GetCronJobsResponse{
+			CronJob:    cronJob,
+			StatusLine: match.Highlight(NewCronJobDetails(cronJob)),
+			Match:      match,
+		}
+		cronJobResponse = append(cronJobResponse, cronJobInfo)
+	}
+	sortMatches(opt, cronJobResponse, func(i int) Match { return cronJobResponse[i].Match })
+	return cronJobResponse, nil
+}
+
+// NewCronJobDetails - render a cronjob as a table row
+func NewCronJobDetails(cronJob batchv1beta1.CronJob) string {
+	suspend := cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+
+	var lastSchedule string
+	if cronJob.Status.LastScheduleTime != nil {
+		lastSchedule = util.FormatAge(cronJob.Status.LastScheduleTime.Time)
+	}
+
+	return fmt.Sprintf(util.CronJobRowTemplate,
+		cronJob.Namespace,
+		cronJob.Name,
+		cronJob.Spec.Schedule,
+		suspend,
+		len(cronJob.Status.Active),
+		orNone(lastSchedule),
+		util.FormatAge(cronJob.CreationTimestamp.Time))
+}
+
+type GetCronJobsResponse struct {
+	CronJob    batchv1beta1.CronJob
+	StatusLine string
+	Match      Match
+}
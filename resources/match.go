@@ -0,0 +1,440 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Match - how a resource matched the search keyword, along with the fields
+// results can be sorted on
+type Match struct {
+	Name      string
+	Namespace string
+	Created   time.Time
+	Score     int
+	Restarts  int32
+	Status    string
+	// Line is the line of the serialized object a keyword was found in with
+	// --deep, when it didn't match on the name or the other fields
+	Line string
+
+	// the text each keyword was found in and where, for highlighting
+	hits []hit
+}
+
+// hit - a keyword found in field at the byte ranges spans
+type hit struct {
+	field string
+	spans [][2]int
+}
+
+// SortKeys - the values accepted by --sort-by
+var SortKeys = []string{"name", "namespace", "age", "restarts", "status"}
+
+// matcher - matches resources against the search keywords using the mode
+// selected on the command line: substring, fuzzy or regular expression.
+// A resource matches if any keyword matches, or all of them with --all.
+type matcher struct {
+	opt         *options.SearchOptions
+	keywords    []string
+	patterns    []*regexp.Regexp
+	excludes    []*regexp.Regexp
+	annotations []annotationFilter
+
+	// the --younger-than / --older-than window, 0 when unbounded
+	now         time.Time
+	youngerThan time.Duration
+	olderThan   time.Duration
+}
+
+// annotationFilter - one --annotation: the annotation must exist and, if a
+// value was given, contain it
+type annotationFilter struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// newMatcher - prepare a matcher for keywords, failing on an invalid --regex
+// pattern before any API call is made
+func newMatcher(opt *options.SearchOptions, keywords []string) (*matcher, error) {
+	if opt.Regex && opt.Fuzzy {
+		return nil, fmt.Errorf("--regex and --fuzzy can't be used together")
+	}
+	if err := validateSortKey(opt.SortBy); err != nil {
+		return nil, err
+	}
+
+	m := &matcher{opt: opt, now: time.Now()}
+	if err := m.parseAgeWindow(); err != nil {
+		return nil, err
+	}
+	for _, keyword := range keywords {
+		if keyword != "" {
+			m.keywords = append(m.keywords, keyword)
+		}
+	}
+	for _, a := range opt.Annotations {
+		kv := strings.SplitN(a, "=", 2)
+		if kv[0] == "" {
+			return nil, fmt.Errorf("invalid --annotation %q, expected key or key=value", a)
+		}
+		filter := annotationFilter{key: kv[0]}
+		if len(kv) == 2 {
+			filter.value, filter.hasValue = kv[1], true
+		}
+		m.annotations = append(m.annotations, filter)
+	}
+	if opt.Regex {
+		for _, keyword := range m.keywords {
+			pattern, err := regexp.Compile(keyword)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --regex pattern %q: %v", keyword, err)
+			}
+			m.patterns = append(m.patterns, pattern)
+		}
+	}
+	for _, exclude := range opt.Exclude {
+		if exclude == "" {
+			continue
+		}
+		if !opt.Regex {
+			exclude = regexp.QuoteMeta(exclude)
+		}
+		pattern, err := regexp.Compile(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --exclude pattern %q: %v", exclude, err)
+		}
+		m.excludes = append(m.excludes, pattern)
+	}
+	return m, nil
+}
+
+// ObjectFilter - a filter matching objects by name like the search does,
+// for the objects delivered by a watch
+func ObjectFilter(opt *options.SearchOptions, keywords []string) (func(runtime.Object) bool, error) {
+	m, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	return func(obj runtime.Object) bool {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return false
+		}
+		_, ok := m.match(accessor)
+		return ok
+	}, nil
+}
+
+// match - check the keywords against the resource name and any extra
+// searchable fields. Without keywords everything matches.
+func (m *matcher) match(obj metav1.Object, fields ...string) (Match, bool) {
+	name := obj.GetName()
+	match := Match{
+		Name:      name,
+		Namespace: obj.GetNamespace(),
+		Created:   obj.GetCreationTimestamp().Time,
+	}
+	if !m.matchAnnotations(obj) || !m.inAgeWindow(match.Created) {
+		return match, false
+	}
+	found := len(m.keywords) == 0
+	candidates := append([]string{name}, fields...)
+	searched := len(candidates)
+	if m.opt.Deep && !found {
+		if r, ok := obj.(runtime.Object); ok {
+			candidates = append(candidates, objectLines(r, m.opt.ShowValues)...)
+		}
+	}
+	for i := range m.keywords {
+		h, score, ok := m.matchKeyword(i, candidates)
+		if !ok {
+			if m.opt.MatchAll {
+				return match, false
+			}
+			continue
+		}
+		if match.Line == "" && fromObjectLines(h.field, candidates[:searched]) {
+			match.Line = h.field
+		}
+		match.hits = append(match.hits, h)
+		match.Score += score
+		found = true
+	}
+	if found && m.excluded(name) {
+		return match, false
+	}
+	return match, found
+}
+
+// parseAgeWindow - parse --younger-than and --older-than. Given both, they
+// select the objects created between the two.
+func (m *matcher) parseAgeWindow() error {
+	var err error
+	if m.opt.YoungerThan != "" {
+		if m.youngerThan, err = util.ParseAge(m.opt.YoungerThan); err != nil || m.youngerThan <= 0 {
+			return fmt.Errorf("invalid --younger-than %q, expected a positive duration like 90m or 7d", m.opt.YoungerThan)
+		}
+	}
+	if m.opt.OlderThan != "" {
+		if m.olderThan, err = util.ParseAge(m.opt.OlderThan); err != nil || m.olderThan <= 0 {
+			return fmt.Errorf("invalid --older-than %q, expected a positive duration like 90m or 7d", m.opt.OlderThan)
+		}
+	}
+	if m.youngerThan > 0 && m.olderThan >= m.youngerThan {
+		return fmt.Errorf("--older-than=%s must be less than --younger-than=%s, nothing can match", m.opt.OlderThan, m.opt.YoungerThan)
+	}
+	return nil
+}
+
+// sinceCutoff - the time --since reaches back to from now, zero when it
+// isn't set
+func sinceCutoff(opt *options.SearchOptions) (time.Time, error) {
+	if opt.Since == "" {
+		return time.Time{}, nil
+	}
+	since, err := util.ParseAge(opt.Since)
+	if err != nil || since <= 0 {
+		return time.Time{}, fmt.Errorf("invalid --since %q, expected a positive duration like 10m or 2h", opt.Since)
+	}
+	return time.Now().Add(-since), nil
+}
+
+// inAgeWindow - report whether something created at created is inside the
+// --younger-than / --older-than window. Without a creation time it isn't.
+func (m *matcher) inAgeWindow(created time.Time) bool {
+	if m.youngerThan == 0 && m.olderThan == 0 {
+		return true
+	}
+	if created.IsZero() {
+		return false
+	}
+	age := m.now.Sub(created)
+	if m.youngerThan > 0 && age >= m.youngerThan {
+		return false
+	}
+	return m.olderThan == 0 || age > m.olderThan
+}
+
+// excluded - report whether name contains one of the --exclude values, or
+// matches one of them with --regex
+func (m *matcher) excluded(name string) bool {
+	for _, pattern := range m.excludes {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchKeyword - check the i-th keyword against candidates, returning where
+// it was found. Fuzzy matches pick the best scoring candidate.
+func (m *matcher) matchKeyword(i int, candidates []string) (hit, int, bool) {
+	if m.patterns != nil {
+		for _, c := range candidates {
+			if locs := m.patterns[i].FindAllStringIndex(c, -1); locs != nil {
+				h := hit{field: c}
+				for _, loc := range locs {
+					h.spans = append(h.spans, [2]int{loc[0], loc[1]})
+				}
+				return h, 0, true
+			}
+		}
+		return hit{}, 0, false
+	}
+	if !m.opt.Fuzzy {
+		for _, c := range candidates {
+			if strings.Contains(c, m.keywords[i]) {
+				return hit{field: c, spans: substringSpans(c, m.keywords[i])}, 0, true
+			}
+		}
+		return hit{}, 0, false
+	}
+
+	keyword := m.keywords[i]
+	if !m.opt.CaseSensitive {
+		keyword = strings.ToLower(keyword)
+	}
+	var best hit
+	bestScore, found := 0, false
+	for _, candidate := range candidates {
+		c := candidate
+		if !m.opt.CaseSensitive {
+			c = strings.ToLower(c)
+		}
+		if score, ok := util.FuzzyMatch(keyword, c); ok && (!found || score > bestScore) {
+			best = hit{field: candidate, spans: util.FuzzySpans(keyword, c)}
+			bestScore, found = score, true
+		}
+	}
+	return best, bestScore, found
+}
+
+// matchAnnotations - apply the --annotation filters, which the API server
+// can't select on
+func (m *matcher) matchAnnotations(obj metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	for _, filter := range m.annotations {
+		value, ok := annotations[filter.key]
+		if !ok || (filter.hasValue && !strings.Contains(value, filter.value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Highlight - color the parts of line the keywords matched. line is a tab
+// separated table row; the cell holding each matched field is highlighted,
+// preferring a cell that is exactly the field over one containing it.
+func (m Match) Highlight(line string) string {
+	if len(m.hits) == 0 || !util.ColorEnabled() {
+		return line
+	}
+	cells := strings.Split(line, "\t")
+	cellSpans := map[int][][2]int{}
+	for _, h := range m.hits {
+		cell := hitCell(cells, h.field)
+		if cell < 0 {
+			continue
+		}
+		offset := strings.Index(cells[cell], h.field)
+		for _, span := range h.spans {
+			cellSpans[cell] = append(cellSpans[cell], [2]int{span[0] + offset, span[1] + offset})
+		}
+	}
+	for cell, spans := range cellSpans {
+		cells[cell] = util.Highlight(cells[cell], mergeSpans(spans))
+	}
+	return strings.Join(cells, "\t")
+}
+
+// hitCell - the index of the cell showing field, or -1
+func hitCell(cells []string, field string) int {
+	for i, c := range cells {
+		if c == field {
+			return i
+		}
+	}
+	for i, c := range cells {
+		if strings.Contains(c, field) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeSpans - sort spans and join the overlapping ones, since several
+// keywords can match the same text
+func mergeSpans(spans [][2]int) [][2]int {
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	var merged [][2]int
+	for _, span := range spans {
+		if n := len(merged); n > 0 && span[0] <= merged[n-1][1] {
+			if span[1] > merged[n-1][1] {
+				merged[n-1][1] = span[1]
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// sortMatches - stable sort results on the --sort-by key, tie-breaking on
+// namespace and name so the output is deterministic. Without --sort-by,
+// fuzzy matches are ranked best first, results of --all-namespaces are grouped
+// by namespace and everything else is sorted by name.
+func sortMatches(opt *options.SearchOptions, results interface{}, match func(i int) Match) {
+	key := opt.SortBy
+	if key == "" {
+		key = "name"
+		if opt.Fuzzy {
+			key = "score"
+		} else if opt.AllNamespaces {
+			key = "namespace"
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := match(i), match(j)
+		if opt.Reverse {
+			a, b = b, a
+		}
+		return a.before(b, key)
+	})
+}
+
+func (a Match) before(b Match, key string) bool {
+	switch key {
+	case "score":
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+	case "namespace":
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	case "age":
+		if !a.Created.Equal(b.Created) {
+			return a.Created.Before(b.Created)
+		}
+	case "restarts":
+		if a.Restarts != b.Restarts {
+			return a.Restarts < b.Restarts
+		}
+	case "status":
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.Namespace < b.Namespace
+}
+
+func validateSortKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	for _, k := range SortKeys {
+		if k == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown --sort-by %q, expected one of: %s", key, strings.Join(SortKeys, "|"))
+}
+
+// substringSpans - the byte ranges of every occurrence of keyword in s
+func substringSpans(s, keyword string) [][2]int {
+	var spans [][2]int
+	for start := 0; ; {
+		i := strings.Index(s[start:], keyword)
+		if i < 0 {
+			return spans
+		}
+		spans = append(spans, [2]int{start + i, start + i + len(keyword)})
+		start += i + len(keyword)
+	}
+}
+
+// orNone - render empty table cells the way kubectl does
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
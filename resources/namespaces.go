@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetNamespaces - a public function for searching namespaces with keyword
+func GetNamespaces(opt *options.SearchOptions, keywords []string) ([]GetNamespacesResponse, error) {
+	var namespaceResponse []GetNamespacesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	namespaceList, err := util.ClientFor(opt).NamespaceList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaceList.Items {
+		// return all namespaces if no keyword specific
+		match, ok := matcher.match(&namespace)
+		if !ok {
+			continue
+		}
+		match.Status = string(namespace.Status.Phase)
+
+		namespaceInfo := GetNamespacesResponse{
+			Namespace:  namespace,
+			StatusLine: match.Highlight(NewNamespaceDetails(namespace)),
+			Match:      match,
+		}
+		namespaceResponse = append(namespaceResponse, namespaceInfo)
+	}
+	sortMatches(opt, namespaceResponse, func(i int) Match { return namespaceResponse[i].Match })
+	return namespaceResponse, nil
+}
+
+// NewNamespaceDetails - render a namespace as a table row
+func NewNamespaceDetails(namespace corev1.Namespace) string {
+	return fmt.Sprintf(util.NamespaceRowTemplate,
+		namespace.Name,
+		namespace.Status.Phase,
+		util.FormatAge(namespace.CreationTimestamp.Time))
+}
+
+type GetNamespacesResponse struct {
+	Namespace  corev1.Namespace
+	StatusLine string
+	Match      Match
+}
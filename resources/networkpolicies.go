@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetNetworkPolicies - a public function for searching network policies with
+// keyword, matching on the policy name or the labels of its pod selector
+func GetNetworkPolicies(opt *options.SearchOptions, keywords []string) ([]GetNetworkPoliciesResponse, error) {
+	var policyResponse []GetNetworkPoliciesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	policyList, err := util.ClientFor(opt).NetworkPolicyList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policyList.Items {
+		// return all policies under namespace if no keyword specific
+		match, ok := matcher.match(&policy, podSelector(policy))
+		if !ok {
+			continue
+		}
+		policyInfo := GetNetworkPoliciesResponse{
+			NetworkPolicy: policy,
+			StatusLine:    match.Highlight(NewNetworkPolicyDetails(policy)),
+			Match:         match,
+		}
+		policyResponse = append(policyResponse, policyInfo)
+	}
+	sortMatches(opt, policyResponse, func(i int) Match { return policyResponse[i].Match })
+	return policyResponse, nil
+}
+
+// NewNetworkPolicyDetails - render a network policy as a table row
+func NewNetworkPolicyDetails(policy networkingv1.NetworkPolicy) string {
+	var types []string
+	for _, t := range policy.Spec.PolicyTypes {
+		types = append(types, string(t))
+	}
+
+	return fmt.Sprintf(util.NetworkPolicyRowTemplate,
+		policy.Namespace,
+		policy.Name,
+		podSelector(policy),
+		orNone(strings.Join(types, ",")),
+		len(policy.Spec.Ingress),
+		len(policy.Spec.Egress),
+		util.FormatAge(policy.CreationTimestamp.Time))
+}
+
+// podSelector - the pods a policy applies to; an empty selector selects every
+// pod in the namespace
+func podSelector(policy networkingv1.NetworkPolicy) string {
+	selector := metav1.FormatLabelSelector(&policy.Spec.PodSelector)
+	if selector == "<none>" {
+		return "<all pods>"
+	}
+	return selector
+}
+
+type GetNetworkPoliciesResponse struct {
+	NetworkPolicy networkingv1.NetworkPolicy
+	StatusLine    string
+	Match         Match
+}
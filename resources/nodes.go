@@ -0,0 +1,136 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeRolePrefix - node role labels look like node-role.kubernetes.io/master
+const nodeRolePrefix = "node-role.kubernetes.io/"
+
+// GetNodes - a public function for searching nodes with keyword. With
+// withPods, the pods scheduled on each matched node are looked up too.
+func GetNodes(opt *options.SearchOptions, keywords []string, withPods bool) ([]GetNodesResponse, error) {
+	var nodeResponse []GetNodesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	taint, err := parseTaintFilter("taint", opt.Taint)
+	if err != nil {
+		return nil, err
+	}
+	nodeList, err := util.ClientFor(opt).NodeList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodeList.Items {
+		if taint != nil && !hasTaint(node, taint) {
+			continue
+		}
+		// return all nodes if no keyword specific
+		match, ok := matcher.match(&node)
+		if !ok {
+			continue
+		}
+		match.Status = nodeStatus(node)
+
+		nodeInfo := GetNodesResponse{
+			Node:       node,
+			StatusLine: match.Highlight(NewNodeDetails(node)),
+			Match:      match,
+		}
+		if withPods {
+			podList, err := util.ClientFor(opt).NodePodList(&node)
+			if err != nil {
+				return nil, err
+			}
+			nodeInfo.Pods = podList.Items
+		}
+		nodeResponse = append(nodeResponse, nodeInfo)
+	}
+	sortMatches(opt, nodeResponse, func(i int) Match { return nodeResponse[i].Match })
+	return nodeResponse, nil
+}
+
+// NewNodeDetails - render a node as a table row
+func NewNodeDetails(node corev1.Node) string {
+	return fmt.Sprintf(util.NodeRowTemplate,
+		node.Name,
+		nodeStatus(node),
+		orNone(nodeRoles(node)),
+		util.FormatAge(node.CreationTimestamp.Time),
+		node.Status.NodeInfo.KubeletVersion)
+}
+
+// NewNodeDetailsWide - render a node as a table row with the `-o wide` columns
+func NewNodeDetailsWide(node corev1.Node) string {
+	info := node.Status.NodeInfo
+	return fmt.Sprintf(util.NodeRowTemplateWide,
+		node.Name,
+		nodeStatus(node),
+		orNone(nodeRoles(node)),
+		util.FormatAge(node.CreationTimestamp.Time),
+		info.KubeletVersion,
+		orNone(nodeAddress(node, corev1.NodeInternalIP)),
+		orNone(nodeAddress(node, corev1.NodeExternalIP)),
+		info.OSImage,
+		info.KernelVersion,
+		info.ContainerRuntimeVersion)
+}
+
+// nodeAddress - the first address of the given type reported by the node
+func nodeAddress(node corev1.Node, addressType corev1.NodeAddressType) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == addressType {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+// nodeStatus - Ready or NotReady, like kubectl, flagging cordoned nodes
+func nodeStatus(node corev1.Node) string {
+	status := "Unknown"
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady {
+			continue
+		}
+		status = "NotReady"
+		if condition.Status == corev1.ConditionTrue {
+			status = "Ready"
+		}
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// nodeRoles - the roles set with node-role.kubernetes.io/<role> labels
+func nodeRoles(node corev1.Node) string {
+	var roles []string
+	for label := range node.Labels {
+		if strings.HasPrefix(label, nodeRolePrefix) {
+			if role := strings.TrimPrefix(label, nodeRolePrefix); role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
+
+type GetNodesResponse struct {
+	Node       corev1.Node
+	StatusLine string
+	// Pods are the pods scheduled on the node, when they were asked for
+	Pods  []corev1.Pod
+	Match Match
+}
@@ -0,0 +1,184 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxOwnerDepth - give up walking an ownership chain after this many owners,
+// so a reference cycle can't loop forever
+const maxOwnerDepth = 10
+
+// Owner - one link of an ownership chain
+type Owner struct {
+	Kind string
+	Name string
+	// Missing is set when the owner no longer exists: the reference dangles
+	Missing bool
+}
+
+func (o Owner) String() string {
+	if o.Missing {
+		return o.Kind + "/" + o.Name + " (deleted)"
+	}
+	return o.Kind + "/" + o.Name
+}
+
+// GetOwners - a public function for searching pods with keyword, along with
+// the chain of controllers owning each of them
+func GetOwners(opt *options.SearchOptions, keywords []string) ([]GetOwnersResponse, error) {
+	var ownersResponse []GetOwnersResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := util.ClientFor(opt).PodList(opt)
+	if err != nil {
+		return nil, err
+	}
+	// pods of one replicaset share their owners, only get each one once
+	owners := newOwnerCache(opt)
+
+	for _, pod := range podList.Items {
+		match, ok := matcher.match(&pod)
+		if !ok {
+			continue
+		}
+		chain, err := owners.chain(&pod)
+		if err != nil {
+			return nil, err
+		}
+		ownersInfo := GetOwnersResponse{
+			Pod:        pod,
+			Owners:     chain,
+			StatusLine: match.Highlight(NewOwnersDetails(pod, chain)),
+			Match:      match,
+		}
+		ownersResponse = append(ownersResponse, ownersInfo)
+	}
+	sortMatches(opt, ownersResponse, func(i int) Match { return ownersResponse[i].Match })
+	return ownersResponse, nil
+}
+
+// OwnerChain - walk the owner references of obj up to the root controller,
+// e.g. ReplicaSet/web-5d8f -> Deployment/web for a pod. The controller
+// reference is followed when there is one, otherwise the first owner. An
+// owner that was deleted, or replaced by a new object of the same name, ends
+// the chain marked Missing.
+func OwnerChain(opt *options.SearchOptions, obj metav1.Object) ([]Owner, error) {
+	return newOwnerCache(opt).chain(obj)
+}
+
+// RootOwners - the root controller of each of objects, e.g. Deployment/web
+// for a pod of one of its replicasets, or nil for an object without owners.
+// Owners shared by several objects are only fetched once.
+func RootOwners(opt *options.SearchOptions, objects []metav1.Object) ([]*Owner, error) {
+	owners := newOwnerCache(opt)
+	roots := make([]*Owner, len(objects))
+	for i, obj := range objects {
+		chain, err := owners.chain(obj)
+		if err != nil {
+			return nil, err
+		}
+		if len(chain) > 0 {
+			roots[i] = &chain[len(chain)-1]
+		}
+	}
+	return roots, nil
+}
+
+// ownerCache - the owners already fetched through client, by UID, nil for a
+// missing one
+type ownerCache struct {
+	client *util.Client
+	owners map[types.UID]metav1.Object
+}
+
+func newOwnerCache(opt *options.SearchOptions) ownerCache {
+	return ownerCache{client: util.ClientFor(opt), owners: map[types.UID]metav1.Object{}}
+}
+
+// get - fetch the owner ref points to, from the cache when possible
+func (c ownerCache) get(namespace string, ref metav1.OwnerReference) (metav1.Object, error) {
+	if owner, ok := c.owners[ref.UID]; ok {
+		return owner, nil
+	}
+	owner, err := c.client.GetOwner(namespace, ref)
+	if apierrors.IsNotFound(err) || (err == nil && owner.GetUID() != ref.UID) {
+		c.owners[ref.UID] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.owners[ref.UID] = owner
+	return owner, nil
+}
+
+func (c ownerCache) chain(obj metav1.Object) ([]Owner, error) {
+	var chain []Owner
+	namespace := obj.GetNamespace()
+	for len(chain) < maxOwnerDepth {
+		ref := ownerRef(obj)
+		if ref == nil {
+			break
+		}
+		owner := Owner{Kind: ref.Kind, Name: ref.Name}
+		next, err := c.get(namespace, *ref)
+		if err != nil {
+			return nil, fmt.Errorf("getting %s: %v", owner, err)
+		}
+		if next == nil {
+			owner.Missing = true
+			chain = append(chain, owner)
+			break
+		}
+		chain = append(chain, owner)
+		obj = next
+	}
+	return chain, nil
+}
+
+// ownerRef - the reference to follow up from obj: its controller, if any
+func ownerRef(obj metav1.Object) *metav1.OwnerReference {
+	if ref := metav1.GetControllerOf(obj); ref != nil {
+		return ref
+	}
+	if refs := obj.GetOwnerReferences(); len(refs) > 0 {
+		return &refs[0]
+	}
+	return nil
+}
+
+// NewOwnersDetails - render a pod and its ownership chain as a table row
+func NewOwnersDetails(pod corev1.Pod, chain []Owner) string {
+	links := make([]string, len(chain))
+	for i, owner := range chain {
+		links[i] = owner.String()
+	}
+	root := ""
+	if len(chain) > 0 {
+		root = chain[len(chain)-1].String()
+	}
+
+	return fmt.Sprintf(util.OwnerRowTemplate,
+		pod.Namespace,
+		pod.Name,
+		orNone(strings.Join(links, " -> ")),
+		orNone(root))
+}
+
+type GetOwnersResponse struct {
+	Pod corev1.Pod
+	// Owners is the ownership chain of the pod, closest owner first
+	Owners     []Owner
+	StatusLine string
+	Match      Match
+}
@@ -1,33 +1,274 @@
 package resources
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/mateo1647/kk/internal/options"
 	"github.com/mateo1647/kk/util"
 	corev1 "k8s.io/api/core/v1"
 )
 
-func GetPods(opt *options.SearchOptions, keyword string) []GetPodsResponse {
+// GetPods - a public function for searching pods with keyword
+func GetPods(opt *options.SearchOptions, keywords []string) ([]GetPodsResponse, error) {
 	var podResponse []GetPodsResponse
-	podList := util.PodList(opt)
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	scheduling, err := newSchedulingFilter(opt.NodeSelector, opt.Toleration)
+	if err != nil {
+		return nil, err
+	}
+	since, err := sinceCutoff(opt)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := util.ClientFor(opt).PodList(opt)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, pod := range podList.Items {
-		// return all services under namespace if no keyword specific
-		if len(keyword) > 0 {
-			match := strings.Contains(pod.Name, keyword)
-			if !match {
+		if !scheduling.matches(pod.Spec) {
+			continue
+		}
+		if !since.IsZero() && lastTerminated(pod).Before(since) {
+			continue
+		}
+		if opt.Image != "" && !hasImage(pod.Spec, opt.Image) {
+			continue
+		}
+		if opt.Status != "" && !hasStatus(pod, opt.Status) {
+			continue
+		}
+		if opt.NotReady {
+			if ready, total, _ := podReadiness(pod); ready == total {
 				continue
 			}
 		}
+		if opt.MinRestarts > 0 {
+			if _, _, restarts := podReadiness(pod); restarts < opt.MinRestarts {
+				continue
+			}
+		}
+		// return all pods under namespace if no keyword specific
+		match, ok := matcher.match(&pod)
+		if !ok {
+			continue
+		}
+		_, _, match.Restarts = podReadiness(pod)
+		match.Status = PodStatus(pod)
+
 		podInfo := GetPodsResponse{
-			Pod: pod,
+			Pod:        pod,
+			StatusLine: match.Highlight(NewPodRow(pod)),
+			Match:      match,
 		}
 		podResponse = append(podResponse, podInfo)
 	}
-	return podResponse
+	sortMatches(opt, podResponse, func(i int) Match { return podResponse[i].Match })
+	if opt.MinRestarts > 0 && opt.SortBy == "" {
+		// looking for flapping pods, the ones restarting most come first
+		sort.SliceStable(podResponse, func(i, j int) bool {
+			return podResponse[i].Match.Restarts > podResponse[j].Match.Restarts
+		})
+	}
+	return podResponse, nil
+}
+
+// lastTerminated - when a container of pod last terminated, whether it is
+// still terminated or was restarted since. Zero if none ever did.
+func lastTerminated(pod corev1.Pod) time.Time {
+	var last time.Time
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated != nil && terminated.FinishedAt.After(last) {
+				last = terminated.FinishedAt.Time
+			}
+		}
+	}
+	return last
+}
+
+// NewPodRow - render a pod as a table row
+func NewPodRow(pod corev1.Pod) string {
+	ready, total, restarts := podReadiness(pod)
+	return fmt.Sprintf(util.PodRowTemplate,
+		pod.Namespace,
+		pod.Name,
+		ready,
+		total,
+		PodStatus(pod),
+		restarts,
+		util.FormatAge(pod.CreationTimestamp.Time))
+}
+
+// NewPodRowWide - render a pod as a table row with the `-o wide` columns
+func NewPodRowWide(pod corev1.Pod) string {
+	ready, total, restarts := podReadiness(pod)
+	return fmt.Sprintf(util.PodRowTemplateWide,
+		pod.Namespace,
+		pod.Name,
+		ready,
+		total,
+		PodStatus(pod),
+		restarts,
+		util.FormatAge(pod.CreationTimestamp.Time),
+		orNone(pod.Status.PodIP),
+		orNone(pod.Spec.NodeName),
+		orNone(pod.Status.NominatedNodeName),
+		orNone(readinessGates(pod)))
+}
+
+// PodStatus - the status kubectl shows for a pod: the reason its containers
+// are waiting or terminated, e.g. CrashLoopBackOff, Completed or Error,
+// Init:<reason> while init containers run, Terminating, or else the phase
+func PodStatus(pod corev1.Pod) string {
+	reason := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		reason = pod.Status.Reason
+	}
+
+	initializing := false
+	for i, c := range pod.Status.InitContainerStatuses {
+		switch {
+		case c.State.Terminated != nil && c.State.Terminated.ExitCode == 0:
+			continue
+		case c.State.Terminated != nil:
+			reason = "Init:" + terminatedReason(c.State.Terminated)
+		case c.State.Waiting != nil && c.State.Waiting.Reason != "" && c.State.Waiting.Reason != "PodInitializing":
+			reason = "Init:" + c.State.Waiting.Reason
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+
+	if !initializing {
+		hasRunning := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			c := pod.Status.ContainerStatuses[i]
+			switch {
+			case c.State.Waiting != nil && c.State.Waiting.Reason != "":
+				reason = c.State.Waiting.Reason
+			case c.State.Terminated != nil:
+				reason = terminatedReason(c.State.Terminated)
+			case c.Ready && c.State.Running != nil:
+				hasRunning = true
+			}
+		}
+		// a pod whose remaining containers still run isn't completed yet
+		if reason == "Completed" && hasRunning {
+			reason = "NotReady"
+			if podReady(pod) {
+				reason = "Running"
+			}
+		}
+	}
+
+	if pod.DeletionTimestamp != nil {
+		if pod.Status.Reason == "NodeLost" {
+			return "Unknown"
+		}
+		return "Terminating"
+	}
+	return reason
+}
+
+// terminatedReason - why a container terminated, falling back to the signal
+// or exit code
+func terminatedReason(state *corev1.ContainerStateTerminated) string {
+	if state.Reason != "" {
+		return state.Reason
+	}
+	if state.Signal != 0 {
+		return fmt.Sprintf("Signal:%d", state.Signal)
+	}
+	return fmt.Sprintf("ExitCode:%d", state.ExitCode)
+}
+
+// podReady - report whether the pod's Ready condition is true
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// hasStatus - report whether the pod's status is status, ignoring case. An
+// init container's Init:<reason> also matches <reason>.
+func hasStatus(pod corev1.Pod, status string) bool {
+	current := PodStatus(pod)
+	return strings.EqualFold(current, status) ||
+		strings.EqualFold(strings.TrimPrefix(current, "Init:"), status)
+}
+
+// readinessGates - how many of the pod's readiness gates are met, e.g. 1/2
+func readinessGates(pod corev1.Pod) string {
+	if len(pod.Spec.ReadinessGates) == 0 {
+		return ""
+	}
+	met := 0
+	for _, gate := range pod.Spec.ReadinessGates {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType && condition.Status == corev1.ConditionTrue {
+				met++
+			}
+		}
+	}
+	return fmt.Sprintf("%d/%d", met, len(pod.Spec.ReadinessGates))
+}
+
+// containerNames - the comma separated names of the containers in spec
+func containerNames(spec corev1.PodSpec) string {
+	var names []string
+	for _, c := range spec.Containers {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// ContainerImages - the comma separated images of the containers in spec
+func ContainerImages(spec corev1.PodSpec) string {
+	var images []string
+	for _, c := range spec.Containers {
+		images = append(images, c.Image)
+	}
+	return strings.Join(images, ",")
+}
+
+// hasImage - report whether any container or init container in spec runs an
+// image containing substr
+func hasImage(spec corev1.PodSpec, substr string) bool {
+	for _, c := range append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...) {
+		if strings.Contains(c.Image, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// podReadiness - count ready containers and sum their restarts
+func podReadiness(pod corev1.Pod) (ready int, total int, restarts int32) {
+	total = len(pod.Spec.Containers)
+	for _, c := range pod.Status.ContainerStatuses {
+		if c.Ready {
+			ready++
+		}
+		restarts += c.RestartCount
+	}
+	return ready, total, restarts
 }
 
 type GetPodsResponse struct {
-	Pod corev1.Pod
+	Pod        corev1.Pod
+	StatusLine string
+	Match      Match
 }
@@ -0,0 +1,216 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// GetRoles - a public function for searching roles with keyword
+func GetRoles(opt *options.SearchOptions, keywords []string) ([]GetRolesResponse, error) {
+	var roleResponse []GetRolesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	roleList, err := util.ClientFor(opt).RoleList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roleList.Items {
+		// return all roles under namespace if no keyword specific
+		match, ok := matcher.match(&role)
+		if !ok {
+			continue
+		}
+		roleInfo := GetRolesResponse{
+			Role:       role,
+			StatusLine: match.Highlight(NewRoleDetails(role)),
+			Match:      match,
+		}
+		roleResponse = append(roleResponse, roleInfo)
+	}
+	sortMatches(opt, roleResponse, func(i int) Match { return roleResponse[i].Match })
+	return roleResponse, nil
+}
+
+// GetClusterRoles - a public function for searching cluster roles with keyword
+func GetClusterRoles(opt *options.SearchOptions, keywords []string) ([]GetClusterRolesResponse, error) {
+	var clusterRoleResponse []GetClusterRolesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleList, err := util.ClientFor(opt).ClusterRoleList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, clusterRole := range clusterRoleList.Items {
+		// return all cluster roles if no keyword specific
+		match, ok := matcher.match(&clusterRole)
+		if !ok {
+			continue
+		}
+		clusterRoleInfo := GetClusterRolesResponse{
+			ClusterRole: clusterRole,
+			StatusLine:  match.Highlight(NewClusterRoleDetails(clusterRole)),
+			Match:       match,
+		}
+		clusterRoleResponse = append(clusterRoleResponse, clusterRoleInfo)
+	}
+	sortMatches(opt, clusterRoleResponse, func(i int) Match { return clusterRoleResponse[i].Match })
+	return clusterRoleResponse, nil
+}
+
+// GetRoleBindings - a public function for searching role bindings with keyword,
+// matching on the binding name, the role it grants or the name of any subject
+// it grants it to, so `kk rolebinding alice` shows what alice is bound to
+func GetRoleBindings(opt *options.SearchOptions, keywords []string) ([]GetRoleBindingsResponse, error) {
+	var roleBindingResponse []GetRoleBindingsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	roleBindingList, err := util.ClientFor(opt).RoleBindingList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, roleBinding := range roleBindingList.Items {
+		// return all role bindings under namespace if no keyword specific
+		fields := append([]string{roleBinding.RoleRef.Name}, subjectNames(roleBinding.Subjects)...)
+		match, ok := matcher.match(&roleBinding, fields...)
+		if !ok {
+			continue
+		}
+		roleBindingInfo := GetRoleBindingsResponse{
+			RoleBinding: roleBinding,
+			StatusLine:  match.Highlight(NewRoleBindingDetails(roleBinding)),
+			Match:       match,
+		}
+		roleBindingResponse = append(roleBindingResponse, roleBindingInfo)
+	}
+	sortMatches(opt, roleBindingResponse, func(i int) Match { return roleBindingResponse[i].Match })
+	return roleBindingResponse, nil
+}
+
+// GetClusterRoleBindings - a public function for searching cluster role bindings
+// with keyword, matching like GetRoleBindings
+func GetClusterRoleBindings(opt *options.SearchOptions, keywords []string) ([]GetClusterRoleBindingsResponse, error) {
+	var clusterBindingResponse []GetClusterRoleBindingsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	clusterBindingList, err := util.ClientFor(opt).ClusterRoleBindingList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, clusterBinding := range clusterBindingList.Items {
+		// return all cluster role bindings if no keyword specific
+		fields := append([]string{clusterBinding.RoleRef.Name}, subjectNames(clusterBinding.Subjects)...)
+		match, ok := matcher.match(&clusterBinding, fields...)
+		if !ok {
+			continue
+		}
+		clusterBindingInfo := GetClusterRoleBindingsResponse{
+			ClusterRoleBinding: clusterBinding,
+			StatusLine:         match.Highlight(NewClusterRoleBindingDetails(clusterBinding)),
+			Match:              match,
+		}
+		clusterBindingResponse = append(clusterBindingResponse, clusterBindingInfo)
+	}
+	sortMatches(opt, clusterBindingResponse, func(i int) Match { return clusterBindingResponse[i].Match })
+	return clusterBindingResponse, nil
+}
+
+// NewRoleDetails - render a role as a table row
+func NewRoleDetails(role rbacv1.Role) string {
+	return fmt.Sprintf(util.RoleRowTemplate,
+		role.Namespace,
+		role.Name,
+		len(role.Rules),
+		util.FormatAge(role.CreationTimestamp.Time))
+}
+
+// NewClusterRoleDetails - render a cluster role as a table row
+func NewClusterRoleDetails(clusterRole rbacv1.ClusterRole) string {
+	return fmt.Sprintf(util.ClusterRoleRowTemplate,
+		clusterRole.Name,
+		len(clusterRole.Rules),
+		util.FormatAge(clusterRole.CreationTimestamp.Time))
+}
+
+// NewRoleBindingDetails - render a role binding as a table row
+func NewRoleBindingDetails(roleBinding rbacv1.RoleBinding) string {
+	return fmt.Sprintf(util.RoleBindingRowTemplate,
+		roleBinding.Namespace,
+		roleBinding.Name,
+		roleRef(roleBinding.RoleRef),
+		orNone(subjects(roleBinding.Subjects)),
+		util.FormatAge(roleBinding.CreationTimestamp.Time))
+}
+
+// NewClusterRoleBindingDetails - render a cluster role binding as a table row
+func NewClusterRoleBindingDetails(clusterBinding rbacv1.ClusterRoleBinding) string {
+	return fmt.Sprintf(util.ClusterBindingRowTemplate,
+		clusterBinding.Name,
+		roleRef(clusterBinding.RoleRef),
+		orNone(subjects(clusterBinding.Subjects)),
+		util.FormatAge(clusterBinding.CreationTimestamp.Time))
+}
+
+func roleRef(ref rbacv1.RoleRef) string {
+	return fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+}
+
+// subjects - render subjects as Kind/name, with the namespace of service accounts
+func subjects(subjects []rbacv1.Subject) string {
+	var rendered []string
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace != "" {
+			rendered = append(rendered, fmt.Sprintf("%s/%s/%s", subject.Kind, subject.Namespace, subject.Name))
+			continue
+		}
+		rendered = append(rendered, fmt.Sprintf("%s/%s", subject.Kind, subject.Name))
+	}
+	return strings.Join(rendered, ", ")
+}
+
+func subjectNames(subjects []rbacv1.Subject) []string {
+	var names []string
+	for _, subject := range subjects {
+		names = append(names, subject.Name)
+	}
+	return names
+}
+
+type GetRolesResponse struct {
+	Role       rbacv1.Role
+	StatusLine string
+	Match      Match
+}
+
+type GetClusterRolesResponse struct {
+	ClusterRole rbacv1.ClusterRole
+	StatusLine  string
+	Match       Match
+}
+
+type GetRoleBindingsResponse struct {
+	RoleBinding rbacv1.RoleBinding
+	StatusLine  string
+	Match       Match
+}
+
+type GetClusterRoleBindingsResponse struct {
+	ClusterRoleBinding rbacv1.ClusterRoleBinding
+	StatusLine         string
+	Match              Match
+}
@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetReplicaSets - a public function for searching replicasets with keyword,
+// matching on the replicaset name or the name of the deployment owning it
+func GetReplicaSets(opt *options.SearchOptions, keywords []string) ([]GetReplicaSetsResponse, error) {
+	var replicaSetResponse []GetReplicaSetsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	replicaSetList, err := util.ClientFor(opt).ReplicaSetList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, replicaSet := range replicaSetList.Items {
+		owner := controllerName(&replicaSet, "Deployment")
+
+		// return all replicasets under namespace if no keyword specific
+		match, ok := matcher.match(&replicaSet, owner)
+		if !ok {
+			continue
+		}
+		replicaSetInfo := GetReplicaSetsResponse{
+			ReplicaSet: replicaSet,
+			Owner:      owner,
+			StatusLine: match.Highlight(NewReplicaSetDetails(replicaSet, owner)),
+			Match:      match,
+		}
+		replicaSetResponse = append(replicaSetResponse, replicaSetInfo)
+	}
+	sortMatches(opt, replicaSetResponse, func(i int) Match { return replicaSetResponse[i].Match })
+	return replicaSetResponse, nil
+}
+
+// NewReplicaSetDetails - render a replicaset as a table row
+func NewReplicaSetDetails(replicaSet appsv1.ReplicaSet, owner string) string {
+	desired := int32(1)
+	if replicaSet.Spec.Replicas != nil {
+		desired = *replicaSet.Spec.Replicas
+	}
+
+	return fmt.Sprintf(util.ReplicaSetRowTemplate,
+		replicaSet.Namespace,
+		replicaSet.Name,
+		orNone(owner),
+		desired,
+		replicaSet.Status.Replicas,
+		replicaSet.Status.ReadyReplicas,
+		util.FormatAge(replicaSet.CreationTimestamp.Time))
+}
+
+// NewReplicaSetDetailsWide - render a replicaset as a table row with the `-o wide` columns
+func NewReplicaSetDetailsWide(replicaSet appsv1.ReplicaSet, owner string) string {
+	desired := int32(1)
+	if replicaSet.Spec.Replicas != nil {
+		desired = *replicaSet.Spec.Replicas
+	}
+
+	return fmt.Sprintf(util.ReplicaSetRowTemplateWide,
+		replicaSet.Namespace,
+		replicaSet.Name,
+		orNone(owner),
+		desired,
+		replicaSet.Status.Replicas,
+		replicaSet.Status.ReadyReplicas,
+		util.FormatAge(replicaSet.CreationTimestamp.Time),
+		containerNames(replicaSet.Spec.Template.Spec),
+		ContainerImages(replicaSet.Spec.Template.Spec),
+		metav1.FormatLabelSelector(replicaSet.Spec.Selector))
+}
+
+// controllerName - return the name of the controller owning obj if it is of the given kind
+func controllerName(obj metav1.Object, kind string) string {
+	if ref := metav1.GetControllerOf(obj); ref != nil && ref.Kind == kind {
+		return ref.Name
+	}
+	return ""
+}
+
+type GetReplicaSetsResponse struct {
+	ReplicaSet appsv1.ReplicaSet
+	// Owner is the name of the deployment owning the replicaset, if any
+	Owner      string
+	StatusLine string
+	Match      Match
+}
@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetReplicationControllers - a public function for searching the legacy
+// replication controllers with keyword, matching on their name
+func GetReplicationControllers(opt *options.SearchOptions, keywords []string) ([]GetReplicationControllersResponse, error) {
+	var rcResponse []GetReplicationControllersResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	rcList, err := util.ClientFor(opt).ReplicationControllerList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rc := range rcList.Items {
+		// return all replication controllers under namespace if no keyword specific
+		match, ok := matcher.match(&rc)
+		if !ok {
+			continue
+		}
+		rcInfo := GetReplicationControllersResponse{
+			ReplicationController: rc,
+			StatusLine:            match.Highlight(NewReplicationControllerDetails(rc)),
+			Match:                 match,
+		}
+		rcResponse = append(rcResponse, rcInfo)
+	}
+	sortMatches(opt, rcResponse, func(i int) Match { return rcResponse[i].Match })
+	return rcResponse, nil
+}
+
+// NewReplicationControllerDetails - render a replication controller as a table row
+func NewReplicationControllerDetails(rc corev1.ReplicationController) string {
+	return fmt.Sprintf(util.RcRowTemplate,
+		rc.Namespace,
+		rc.Name,
+		rcDesired(rc),
+		rc.Status.Replicas,
+		rc.Status.ReadyReplicas,
+		util.FormatAge(rc.CreationTimestamp.Time))
+}
+
+// NewReplicationControllerDetailsWide - render a replication controller as a
+// table row with the `-o wide` columns
+func NewReplicationControllerDetailsWide(rc corev1.ReplicationController) string {
+	containers, images := "<none>", "<none>"
+	if rc.Spec.Template != nil {
+		containers = containerNames(rc.Spec.Template.Spec)
+		images = ContainerImages(rc.Spec.Template.Spec)
+	}
+
+	return fmt.Sprintf(util.RcRowTemplateWide,
+		rc.Namespace,
+		rc.Name,
+		rcDesired(rc),
+		rc.Status.Replicas,
+		rc.Status.ReadyReplicas,
+		util.FormatAge(rc.CreationTimestamp.Time),
+		containers,
+		images,
+		orNone(util.KeysString(rc.Spec.Selector)))
+}
+
+// rcDesired - the replicas asked for, 1 when left unset like the API defaults it
+func rcDesired(rc corev1.ReplicationController) int32 {
+	if rc.Spec.Replicas != nil {
+		return *rc.Spec.Replicas
+	}
+	return 1
+}
+
+type GetReplicationControllersResponse struct {
+	ReplicationController corev1.ReplicationController
+	StatusLine            string
+	Match                 Match
+}
@@ -0,0 +1,154 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetPodRequests - a public function for searching pods with keyword, along
+// with the CPU and memory they request and are limited to
+func GetPodRequests(opt *options.SearchOptions, keywords []string) ([]GetPodRequestsResponse, error) {
+	var podRequestsResponse []GetPodRequestsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := util.ClientFor(opt).PodList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range podList.Items {
+		match, ok := matcher.match(&pod)
+		if !ok {
+			continue
+		}
+		requests, limits := effectiveResources(pod)
+		podRequestsInfo := GetPodRequestsResponse{
+			Pod:        pod,
+			Requests:   requests,
+			Limits:     limits,
+			StatusLine: match.Highlight(NewPodRequestsDetails(pod, requests, limits)),
+			Match:      match,
+		}
+		podRequestsResponse = append(podRequestsResponse, podRequestsInfo)
+	}
+	sortMatches(opt, podRequestsResponse, func(i int) Match { return podRequestsResponse[i].Match })
+	return podRequestsResponse, nil
+}
+
+// NewPodRequestsDetails - render the requests and limits of a pod as a table row
+func NewPodRequestsDetails(pod corev1.Pod, requests, limits corev1.ResourceList) string {
+	return fmt.Sprintf(util.RequestsRowTemplate,
+		pod.Namespace,
+		pod.Name,
+		cpuString(requests),
+		cpuString(limits),
+		memoryString(requests),
+		memoryString(limits))
+}
+
+// NamespaceTotals - sum the requests and limits of pods per namespace, sorted
+// by namespace
+func NamespaceTotals(pods []GetPodRequestsResponse) []NamespaceTotal {
+	byNamespace := map[string]*NamespaceTotal{}
+	for _, pod := range pods {
+		total, ok := byNamespace[pod.Pod.Namespace]
+		if !ok {
+			total = &NamespaceTotal{
+				Namespace: pod.Pod.Namespace,
+				Requests:  corev1.ResourceList{},
+				Limits:    corev1.ResourceList{},
+			}
+			byNamespace[pod.Pod.Namespace] = total
+		}
+		total.Pods++
+		addResources(total.Requests, pod.Requests)
+		addResources(total.Limits, pod.Limits)
+	}
+
+	totals := make([]NamespaceTotal, 0, len(byNamespace))
+	for _, total := range byNamespace {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Namespace < totals[j].Namespace })
+	return totals
+}
+
+// NewNamespaceTotalDetails - render the totals of a namespace as a table row
+func NewNamespaceTotalDetails(total NamespaceTotal) string {
+	return fmt.Sprintf(util.NamespaceTotalsRowTemplate,
+		total.Namespace,
+		total.Pods,
+		cpuString(total.Requests),
+		cpuString(total.Limits),
+		memoryString(total.Requests),
+		memoryString(total.Limits))
+}
+
+// effectiveResources - the requests and limits the scheduler accounts a pod
+// for: per resource, the larger of the sum over its containers and the
+// largest of its init containers, which run one at a time before them, plus
+// the pod overhead. Containers that don't set a resource count as zero.
+func effectiveResources(pod corev1.Pod) (requests corev1.ResourceList, limits corev1.ResourceList) {
+	requests, limits = corev1.ResourceList{}, corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		addResources(requests, c.Resources.Requests)
+		addResources(limits, c.Resources.Limits)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		maxResources(requests, c.Resources.Requests)
+		maxResources(limits, c.Resources.Limits)
+	}
+	addResources(requests, pod.Spec.Overhead)
+	addResources(limits, pod.Spec.Overhead)
+	return requests, limits
+}
+
+// addResources - add every quantity of from to to
+func addResources(to, from corev1.ResourceList) {
+	for name, q := range from {
+		total := to[name]
+		total.Add(q)
+		to[name] = total
+	}
+}
+
+// maxResources - raise every quantity of to to the one in from, if larger
+func maxResources(to, from corev1.ResourceList) {
+	for name, q := range from {
+		if current, ok := to[name]; !ok || q.Cmp(current) > 0 {
+			to[name] = q.DeepCopy()
+		}
+	}
+}
+
+func cpuString(list corev1.ResourceList) string {
+	q := list[corev1.ResourceCPU]
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+func memoryString(list corev1.ResourceList) string {
+	q := list[corev1.ResourceMemory]
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
+
+type GetPodRequestsResponse struct {
+	Pod        corev1.Pod
+	Requests   corev1.ResourceList
+	Limits     corev1.ResourceList
+	StatusLine string
+	Match      Match
+}
+
+// NamespaceTotal - the requests and limits of the matching pods of a namespace
+type NamespaceTotal struct {
+	Namespace string
+	Pods      int
+	Requests  corev1.ResourceList
+	Limits    corev1.ResourceList
+}
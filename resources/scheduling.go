@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// taintFilter - a key[=value][:effect] given to --toleration or --taint; the
+// value and effect only have to match when given
+type taintFilter struct {
+	key    string
+	value  string
+	effect corev1.TaintEffect
+
+	hasValue bool
+}
+
+// parseTaintFilter - parse key[=value][:effect], e.g. gpu=true:NoSchedule
+func parseTaintFilter(flag string, spec string) (*taintFilter, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	filter := &taintFilter{}
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		filter.effect = corev1.TaintEffect(spec[i+1:])
+		spec = spec[:i]
+		switch filter.effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return nil, fmt.Errorf("invalid --%s effect %q, expected one of: NoSchedule|PreferNoSchedule|NoExecute", flag, filter.effect)
+		}
+	}
+	if i := strings.Index(spec, "="); i >= 0 {
+		filter.value, filter.hasValue = spec[i+1:], true
+		spec = spec[:i]
+	}
+	if spec == "" {
+		return nil, fmt.Errorf("invalid --%s, expected key[=value][:effect]", flag)
+	}
+	filter.key = spec
+	return filter, nil
+}
+
+// matchesTaint - report whether taint has the key, value and effect asked for
+func (f *taintFilter) matchesTaint(taint corev1.Taint) bool {
+	if taint.Key != f.key {
+		return false
+	}
+	if f.hasValue && taint.Value != f.value {
+		return false
+	}
+	return f.effect == "" || taint.Effect == f.effect
+}
+
+// matchesToleration - report whether toleration is for the key asked for,
+// tolerating the value and effect when given. A toleration of every value
+// of the key, or of every effect, tolerates the ones asked for.
+func (f *taintFilter) matchesToleration(toleration corev1.Toleration) bool {
+	if toleration.Key != f.key {
+		return false
+	}
+	if f.hasValue && toleration.Operator != corev1.TolerationOpExists && toleration.Value != f.value {
+		return false
+	}
+	return f.effect == "" || toleration.Effect == "" || toleration.Effect == f.effect
+}
+
+// schedulingFilter - the --node-selector and --toleration filters on pods
+type schedulingFilter struct {
+	nodeSelector labels.Selector
+	toleration   *taintFilter
+}
+
+func newSchedulingFilter(nodeSelector string, toleration string) (*schedulingFilter, error) {
+	filter := &schedulingFilter{}
+	if nodeSelector != "" {
+		selector, err := labels.Parse(nodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --node-selector %q: %v", nodeSelector, err)
+		}
+		filter.nodeSelector = selector
+	}
+	var err error
+	if filter.toleration, err = parseTaintFilter("toleration", toleration); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// matches - report whether spec targets the nodes asked for
+func (f *schedulingFilter) matches(spec corev1.PodSpec) bool {
+	if f.nodeSelector != nil && !f.nodeSelector.Matches(labels.Set(spec.NodeSelector)) {
+		return false
+	}
+	if f.toleration != nil {
+		for _, toleration := range spec.Tolerations {
+			if f.toleration.matchesToleration(toleration) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// hasTaint - report whether node has a taint matching filter
+func hasTaint(node corev1.Node, filter *taintFilter) bool {
+	for _, taint := range node.Spec.Taints {
+		if filter.matchesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetSecrets - a public function for searching secrets with keyword. With
+// --key or --value-contains only the secrets with a matching key are kept,
+// and Keys holds the keys that matched.
+func GetSecrets(opt *options.SearchOptions, keywords []string) ([]GetSecretsResponse, error) {
+	var secretResponse []GetSecretsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	secretList, err := util.ClientFor(opt).SecretList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range secretList.Items {
+		// return all secrets under namespace if no keyword specific
+		match, ok := matcher.match(&secret)
+		if !ok {
+			continue
+		}
+		keys, ok := matchingKeys(opt, secret.Data)
+		if !ok {
+			continue
+		}
+		secretInfo := GetSecretsResponse{
+			Secret:     secret,
+			StatusLine: match.Highlight(NewSecretDetails(secret)),
+			Match:      match,
+			Keys:       keys,
+		}
+		secretResponse = append(secretResponse, secretInfo)
+	}
+	sortMatches(opt, secretResponse, func(i int) Match { return secretResponse[i].Match })
+	return secretResponse, nil
+}
+
+// NewSecretDetails - render a secret as a table row
+func NewSecretDetails(secret corev1.Secret) string {
+	return fmt.Sprintf(util.SecretRowTemplate,
+		secret.Namespace,
+		secret.Name,
+		secret.Type,
+		len(secret.Data),
+		util.FormatAge(secret.CreationTimestamp.Time))
+}
+
+// NewSecretKeyDetails - render one table row per key of a secret, in the order
+// of keys. The client has already decoded data from base64; values are only
+// shown when showValues is set, otherwise the row holds the size of the value.
+func NewSecretKeyDetails(secret corev1.Secret, keys []string, showValues bool) []string {
+	if len(keys) == 0 {
+		return []string{fmt.Sprintf(util.SecretKeyRowTemplate, secret.Namespace, secret.Name, secret.Type, "<none>", "")}
+	}
+
+	var lines []string
+	for _, key := range keys {
+		value := fmt.Sprintf("%d bytes", len(secret.Data[key]))
+		if showValues {
+			value = secretValue(secret.Data[key])
+		}
+		lines = append(lines, fmt.Sprintf(util.SecretKeyRowTemplate, secret.Namespace, secret.Name, secret.Type, key, value))
+	}
+	return lines
+}
+
+// secretValue - print a value as text, quoted if it would break the table
+func secretValue(value []byte) string {
+	if !utf8.Valid(value) {
+		return fmt.Sprintf("<binary: %d bytes>", len(value))
+	}
+	text := string(value)
+	if strings.ContainsAny(text, "\t\n\r") {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+type GetSecretsResponse struct {
+	Secret     corev1.Secret
+	StatusLine string
+	Match      Match
+	Keys       []string
+}
@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetServiceAccounts - a public function for searching service accounts with keyword
+func GetServiceAccounts(opt *options.SearchOptions, keywords []string) ([]GetServiceAccountsResponse, error) {
+	var serviceAccountResponse []GetServiceAccountsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccountList, err := util.ClientFor(opt).ServiceAccountList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, serviceAccount := range serviceAccountList.Items {
+		// return all service accounts under namespace if no keyword specific
+		match, ok := matcher.match(&serviceAccount)
+		if !ok {
+			continue
+		}
+		serviceAccountInfo := GetServiceAccountsResponse{
+			ServiceAccount: serviceAccount,
+			StatusLine:     match.Highlight(NewServiceAccountDetails(serviceAccount)),
+			Match:          match,
+		}
+		serviceAccountResponse = append(serviceAccountResponse, serviceAccountInfo)
+	}
+	sortMatches(opt, serviceAccountResponse, func(i int) Match { return serviceAccountResponse[i].Match })
+	return serviceAccountResponse, nil
+}
+
+// NewServiceAccountDetails - render a service account as a table row
+func NewServiceAccountDetails(serviceAccount corev1.ServiceAccount) string {
+	return fmt.Sprintf(util.ServiceAccountRowTemplate,
+		serviceAccount.Namespace,
+		serviceAccount.Name,
+		len(serviceAccount.Secrets),
+		len(serviceAccount.ImagePullSecrets),
+		util.FormatAge(serviceAccount.CreationTimestamp.Time))
+}
+
+type GetServiceAccountsResponse struct {
+	ServiceAccount corev1.ServiceAccount
+	StatusLine     string
+	Match          Match
+}
@@ -1,34 +1,40 @@
 package resources
 
 import (
-	"strings"
-
 	"github.com/mateo1647/kk/internal/options"
 	"github.com/mateo1647/kk/util"
 	v1 "k8s.io/api/core/v1"
 )
 
 // Services - a public function for searching services with keyword
-func GetServices(opt *options.SearchOptions, keyword string) []GetServicesResponse {
+func GetServices(opt *options.SearchOptions, keywords []string) ([]GetServicesResponse, error) {
 	var serviceResponse []GetServicesResponse
-	serviceList := util.ServiceList(opt)
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	serviceList, err := util.ClientFor(opt).ServiceList(opt)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, service := range serviceList.Items {
 		// return all services under namespace if no keyword specific
-		if len(keyword) > 0 {
-			match := strings.Contains(service.Name, keyword)
-			if !match {
-				continue
-			}
+		match, ok := matcher.match(&service)
+		if !ok {
+			continue
 		}
 		serviceInfo := GetServicesResponse{
 			Service: service,
+			Match:   match,
 		}
 		serviceResponse = append(serviceResponse, serviceInfo)
 	}
-	return serviceResponse
+	sortMatches(opt, serviceResponse, func(i int) Match { return serviceResponse[i].Match })
+	return serviceResponse, nil
 }
 
 type GetServicesResponse struct {
 	Service v1.Service
+	Match   Match
 }
@@ -3,52 +3,70 @@ package resources
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mateo1647/kk/internal/options"
-	"github.com/mateo1647/kk/pkg/client"
-
 	"github.com/mateo1647/kk/util"
-	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-)
-
-var (
-	clientset = client.InitClient()
 )
 
-// Services - a public function for searching services with keyword
-func GetServicesandPods(opt *options.SearchOptions, keyword string) []GetServicesandPodsResponse {
-	//ns, o := util.SetOptions(opt)
+// Services - a public function for searching services with keyword. With
+// withEndpoints, the addresses backing each service are looked up too.
+func GetServicesandPods(opt *options.SearchOptions, keywords []string, withEndpoints bool) ([]GetServicesandPodsResponse, error) {
+	//ns, o := util.ClientFor(opt).SetOptions(opt)
 	var serviceResponse []GetServicesandPodsResponse
-	serviceList := util.ServiceList(opt)
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	serviceList, err := util.ClientFor(opt).ServiceList(opt)
+	if err != nil {
+		return nil, err
+	}
 	for _, service := range serviceList.Items {
-		selector := service.Spec.Selector
-		if len(keyword) > 0 {
-			match := strings.Contains(service.Name, keyword)
-			if !match {
-				continue
-			}
+		if opt.ServiceType != "" && !strings.EqualFold(string(service.Spec.Type), opt.ServiceType) {
+			continue
+		}
+		match, ok := matcher.match(&service)
+		if !ok {
+			continue
 		}
-		if len(selector) > 0 {
-			ns, _ := util.SetOptions(opt)
-			podList, err := clientset.CoreV1().Pods(ns).List(metav1.ListOptions{LabelSelector: util.KeysString(selector)})
+		headerLine := fmt.Sprintf(util.ServiceHeader)
+		serviceInfo := GetServicesandPodsResponse{
+			Service:    service,
+			Headerline: headerLine,
+			StatusLine: match.Highlight(NewServiceDetails(service)),
+			Match:      match,
+		}
+		// services without a selector, e.g. ExternalName ones, have no pods
+		if len(service.Spec.Selector) > 0 {
+			podList, err := util.ClientFor(opt).ServicePodList(&service)
 			if err != nil {
-				log.WithFields(log.Fields{
-					"err": err.Error(),
-				}).Debug("Unable to get service and pod List")
+				return nil, err
 			}
-			var podResponse []PodResponse
 			for _, pod := range podList.Items {
-				podResponse = append(podResponse, NewPodDetails(pod))
-
+				serviceInfo.PodResponse = append(serviceInfo.PodResponse, NewPodDetails(pod))
 			}
-			headerLine := fmt.Sprintf(util.ServiceHeader)
-			serviceInfo := GetServicesandPodsResponse{Service: service, Headerline: headerLine, PodResponse: podResponse}
-			serviceResponse = append(serviceResponse, serviceInfo)
 		}
+		if withEndpoints {
+			endpoints, err := util.ClientFor(opt).ServiceEndpoints(&service)
+			if err != nil {
+				return nil, err
+			}
+			if endpoints != nil {
+				ready, notReady := EndpointAddresses(*endpoints)
+				for _, address := range ready {
+					serviceInfo.EndpointLines = append(serviceInfo.EndpointLines, address+"\tready")
+				}
+				for _, address := range notReady {
+					serviceInfo.EndpointLines = append(serviceInfo.EndpointLines, address+"\tnot ready")
+				}
+			}
+		}
+		serviceResponse = append(serviceResponse, serviceInfo)
 	}
-	return serviceResponse
+	sortMatches(opt, serviceResponse, func(i int) Match { return serviceResponse[i].Match })
+	return serviceResponse, nil
 }
 
 type PodResponse struct {
@@ -69,7 +87,10 @@ func NewPodDetails(pod v1.Pod) PodResponse {
 	}
 	//var statusLine string
 	//buf := bytes.NewBuffer(nil)
-	age := &util.Age{Time: pod.Status.StartTime.Time}
+	var started time.Time
+	if pod.Status.StartTime != nil {
+		started = pod.Status.StartTime.Time
+	}
 	//w := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
 	//headerLine := fmt.Sprintf(util.ServiceHeader)
 	statusLine := fmt.Sprintf(util.ServiceRowTemplate,
@@ -77,15 +98,74 @@ func NewPodDetails(pod v1.Pod) PodResponse {
 		pod.Name,
 		ready,
 		total,
-		pod.Status.Phase,
+		PodStatus(pod),
 		restarts,
-		age.Relative())
+		util.FormatAge(started))
 
 	return PodResponse{StatusLine: statusLine}
 }
 
+// NewServiceDetails - render a service as a table row, like kubectl get svc
+func NewServiceDetails(service v1.Service) string {
+	clusterIP := service.Spec.ClusterIP
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		clusterIP = ""
+	}
+	return fmt.Sprintf(util.SvcRowTemplate,
+		service.Namespace,
+		service.Name,
+		string(service.Spec.Type),
+		orNone(clusterIP),
+		externalIPs(service),
+		orNone(servicePorts(service)),
+		util.FormatAge(service.CreationTimestamp.Time))
+}
+
+// externalIPs - the addresses a service is reachable on from outside the
+// cluster: the load balancer ingress and spec.externalIPs, or the external
+// name. A load balancer not provisioned yet is <pending>.
+func externalIPs(service v1.Service) string {
+	switch service.Spec.Type {
+	case v1.ServiceTypeExternalName:
+		return orNone(service.Spec.ExternalName)
+	case v1.ServiceTypeLoadBalancer:
+		var addresses []string
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			} else if ingress.Hostname != "" {
+				addresses = append(addresses, ingress.Hostname)
+			}
+		}
+		addresses = append(addresses, service.Spec.ExternalIPs...)
+		if len(addresses) == 0 {
+			return "<pending>"
+		}
+		return strings.Join(addresses, ",")
+	}
+	return orNone(strings.Join(service.Spec.ExternalIPs, ","))
+}
+
+// servicePorts - the ports of a service like kubectl shows them, e.g.
+// 80:30080/TCP with the node port of NodePort and LoadBalancer services
+func servicePorts(service v1.Service) string {
+	ports := make([]string, len(service.Spec.Ports))
+	for i, port := range service.Spec.Ports {
+		if port.NodePort > 0 {
+			ports[i] = fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, port.Protocol)
+		} else {
+			ports[i] = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+		}
+	}
+	return strings.Join(ports, ",")
+}
+
 type GetServicesandPodsResponse struct {
 	Service     v1.Service
 	Headerline  string
+	StatusLine  string
 	PodResponse []PodResponse
+	// EndpointLines lists each backing address with its readiness
+	EndpointLines []string
+	Match         Match
 }
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// GetStatefulSets - a public function for searching statefulsets with keyword
+func GetStatefulSets(opt *options.SearchOptions, keywords []string) ([]GetStatefulSetsResponse, error) {
+	var statefulSetResponse []GetStatefulSetsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	statefulSetList, err := util.ClientFor(opt).StatefulSetList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statefulSet := range statefulSetList.Items {
+		if opt.Image != "" && !hasImage(statefulSet.Spec.Template.Spec, opt.Image) {
+			continue
+		}
+		if opt.NotRolledOut && statefulSetRolledOut(statefulSet) {
+			continue
+		}
+		// return all statefulsets under namespace if no keyword specific
+		match, ok := matcher.match(&statefulSet)
+		if !ok {
+			continue
+		}
+		statefulSetInfo := GetStatefulSetsResponse{
+			StatefulSet: statefulSet,
+			StatusLine:  match.Highlight(NewStatefulSetDetails(statefulSet)),
+			Match:       match,
+		}
+		statefulSetResponse = append(statefulSetResponse, statefulSetInfo)
+	}
+	sortMatches(opt, statefulSetResponse, func(i int) Match { return statefulSetResponse[i].Match })
+	return statefulSetResponse, nil
+}
+
+// NewStatefulSetDetails - render a statefulset as a table row
+func NewStatefulSetDetails(statefulSet appsv1.StatefulSet) string {
+	return fmt.Sprintf(util.StatefulsetRowTemplate,
+		statefulSet.Namespace,
+		statefulSet.Name,
+		statefulSet.Status.ReadyReplicas,
+		statefulSetReplicas(statefulSet),
+		orNone(statefulSet.Status.CurrentRevision),
+		orNone(statefulSet.Status.UpdateRevision),
+		util.FormatAge(statefulSet.CreationTimestamp.Time))
+}
+
+// NewStatefulSetDetailsWide - render a statefulset as a table row with the `-o wide` columns
+func NewStatefulSetDetailsWide(statefulSet appsv1.StatefulSet) string {
+	return fmt.Sprintf(util.StatefulsetRowTemplateWide,
+		statefulSet.Namespace,
+		statefulSet.Name,
+		statefulSet.Status.ReadyReplicas,
+		statefulSetReplicas(statefulSet),
+		orNone(statefulSet.Status.CurrentRevision),
+		orNone(statefulSet.Status.UpdateRevision),
+		util.FormatAge(statefulSet.CreationTimestamp.Time),
+		containerNames(statefulSet.Spec.Template.Spec),
+		ContainerImages(statefulSet.Spec.Template.Spec))
+}
+
+// statefulSetReplicas - spec.replicas, which the API server defaults to 1
+func statefulSetReplicas(statefulSet appsv1.StatefulSet) int32 {
+	if statefulSet.Spec.Replicas != nil {
+		return *statefulSet.Spec.Replicas
+	}
+	return 1
+}
+
+// statefulSetRolledOut - report whether every pod of a statefulset runs its
+// latest revision. Until then the current and update revisions differ.
+func statefulSetRolledOut(statefulSet appsv1.StatefulSet) bool {
+	return statefulSet.Status.UpdateRevision == "" ||
+		statefulSet.Status.CurrentRevision == statefulSet.Status.UpdateRevision
+}
+
+type GetStatefulSetsResponse struct {
+	StatefulSet appsv1.StatefulSet
+	StatusLine  string
+	Match       Match
+}
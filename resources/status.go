@@ -0,0 +1,29 @@
+package resources
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ObjectStatus - the status column kk shows for obj, for the kinds that have
+// one, or "" for the rest
+func ObjectStatus(obj runtime.Object) string {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		return PodStatus(*o)
+	case *corev1.Node:
+		return nodeStatus(*o)
+	case *corev1.Namespace:
+		return string(o.Status.Phase)
+	case *corev1.PersistentVolumeClaim:
+		return string(o.Status.Phase)
+	case *corev1.PersistentVolume:
+		return string(o.Status.Phase)
+	case *corev1.Event:
+		return o.Type
+	case *batchv1.Job:
+		return JobStatus(*o)
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// default class annotations, the beta one is still set by older installers
+const (
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// GetStorageClasses - a public function for searching storage classes with
+// keyword, matching on the class name or its provisioner
+func GetStorageClasses(opt *options.SearchOptions, keywords []string) ([]GetStorageClassesResponse, error) {
+	var storageClassResponse []GetStorageClassesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	storageClassList, err := util.ClientFor(opt).StorageClassList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storageClass := range storageClassList.Items {
+		// return all storage classes if no keyword specific
+		match, ok := matcher.match(&storageClass, storageClass.Provisioner)
+		if !ok {
+			continue
+		}
+		storageClassInfo := GetStorageClassesResponse{
+			StorageClass: storageClass,
+			StatusLine:   match.Highlight(NewStorageClassDetails(storageClass)),
+			Match:        match,
+		}
+		storageClassResponse = append(storageClassResponse, storageClassInfo)
+	}
+	sortMatches(opt, storageClassResponse, func(i int) Match { return storageClassResponse[i].Match })
+	return storageClassResponse, nil
+}
+
+// NewStorageClassDetails - render a storage class as a table row
+func NewStorageClassDetails(storageClass storagev1.StorageClass) string {
+	// the API server defaults both, but be safe with objects from older servers
+	reclaimPolicy := "Delete"
+	if storageClass.ReclaimPolicy != nil {
+		reclaimPolicy = string(*storageClass.ReclaimPolicy)
+	}
+	bindingMode := string(storagev1.VolumeBindingImmediate)
+	if storageClass.VolumeBindingMode != nil {
+		bindingMode = string(*storageClass.VolumeBindingMode)
+	}
+
+	return fmt.Sprintf(util.StorageClassRowTemplate,
+		storageClass.Name,
+		storageClass.Provisioner,
+		reclaimPolicy,
+		bindingMode,
+		isDefaultStorageClass(storageClass),
+		util.FormatAge(storageClass.CreationTimestamp.Time))
+}
+
+func isDefaultStorageClass(storageClass storagev1.StorageClass) bool {
+	return storageClass.Annotations[defaultStorageClassAnnotation] == "true" ||
+		storageClass.Annotations[betaDefaultStorageClassAnnotation] == "true"
+}
+
+// GetVolumeAttachments - a public function for searching volume attachments
+// with keyword, matching on the attachment name, its volume or its node
+func GetVolumeAttachments(opt *options.SearchOptions, keywords []string) ([]GetVolumeAttachmentsResponse, error) {
+	var attachmentResponse []GetVolumeAttachmentsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	attachmentList, err := util.ClientFor(opt).VolumeAttachmentList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachmentList.Items {
+		// return all attachments if no keyword specific
+		match, ok := matcher.match(&attachment, attachedVolume(attachment), attachment.Spec.NodeName)
+		if !ok {
+			continue
+		}
+		attachmentInfo := GetVolumeAttachmentsResponse{
+			VolumeAttachment: attachment,
+			StatusLine:       match.Highlight(NewVolumeAttachmentDetails(attachment)),
+			Match:            match,
+		}
+		attachmentResponse = append(attachmentResponse, attachmentInfo)
+	}
+	sortMatches(opt, attachmentResponse, func(i int) Match { return attachmentResponse[i].Match })
+	return attachmentResponse, nil
+}
+
+// NewVolumeAttachmentDetails - render a volume attachment as a table row
+func NewVolumeAttachmentDetails(attachment storagev1.VolumeAttachment) string {
+	return fmt.Sprintf(util.VaRowTemplate,
+		attachment.Name,
+		attachment.Spec.Attacher,
+		orNone(attachedVolume(attachment)),
+		attachment.Spec.NodeName,
+		attachment.Status.Attached,
+		util.FormatAge(attachment.CreationTimestamp.Time))
+}
+
+func attachedVolume(attachment storagev1.VolumeAttachment) string {
+	if attachment.Spec.Source.PersistentVolumeName != nil {
+		return *attachment.Spec.Source.PersistentVolumeName
+	}
+	return ""
+}
+
+type GetStorageClassesResponse struct {
+	StorageClass storagev1.StorageClass
+	StatusLine   string
+	Match        Match
+}
+
+type GetVolumeAttachmentsResponse struct {
+	VolumeAttachment storagev1.VolumeAttachment
+	StatusLine       string
+	Match            Match
+}
@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GetTopPods - a public function for searching pods with keyword, along with
+// their CPU and memory usage from the metrics API
+func GetTopPods(opt *options.SearchOptions, keywords []string) ([]GetTopPodsResponse, error) {
+	var topPodsResponse []GetTopPodsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	podMetrics, err := util.ClientFor(opt).PodMetricsList(opt)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := util.ClientFor(opt).PodList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]*util.PodMetrics, len(podMetrics))
+	for i := range podMetrics {
+		usage[podMetrics[i].Namespace+"/"+podMetrics[i].Name] = &podMetrics[i]
+	}
+	for _, pod := range podList.Items {
+		match, ok := matcher.match(&pod)
+		if !ok {
+			continue
+		}
+		metrics := usage[pod.Namespace+"/"+pod.Name]
+		topPodInfo := GetTopPodsResponse{
+			Pod:        pod,
+			Metrics:    metrics,
+			StatusLine: match.Highlight(NewTopPodDetails(pod, metrics)),
+			Match:      match,
+		}
+		topPodsResponse = append(topPodsResponse, topPodInfo)
+	}
+	sortMatches(opt, topPodsResponse, func(i int) Match { return topPodsResponse[i].Match })
+	return topPodsResponse, nil
+}
+
+// NewTopPodDetails - render the usage of a pod as a table row, with how much
+// of its requests and limits it uses. metrics is nil for a pod metrics-server
+// hasn't scraped yet.
+func NewTopPodDetails(pod corev1.Pod, metrics *util.PodMetrics) string {
+	cpu, memory := "<unknown>", "<unknown>"
+	cpuRequest, cpuLimit, memoryRequest, memoryLimit := "<none>", "<none>", "<none>", "<none>"
+	if metrics != nil {
+		cpuUsage := containerUsage(metrics, corev1.ResourceCPU)
+		memoryUsage := containerUsage(metrics, corev1.ResourceMemory)
+		cpu = fmt.Sprintf("%dm", cpuUsage.MilliValue())
+		memory = fmt.Sprintf("%dMi", memoryUsage.Value()/(1024*1024))
+
+		requests, limits := podResources(pod)
+		cpuRequest = utilization(cpuUsage, requests, corev1.ResourceCPU)
+		cpuLimit = utilization(cpuUsage, limits, corev1.ResourceCPU)
+		memoryRequest = utilization(memoryUsage, requests, corev1.ResourceMemory)
+		memoryLimit = utilization(memoryUsage, limits, corev1.ResourceMemory)
+	}
+
+	return fmt.Sprintf(util.TopPodRowTemplate,
+		pod.Namespace,
+		pod.Name,
+		cpu,
+		cpuRequest,
+		cpuLimit,
+		memory,
+		memoryRequest,
+		memoryLimit)
+}
+
+// containerUsage - the usage of name summed over the containers of metrics
+func containerUsage(metrics *util.PodMetrics, name corev1.ResourceName) resource.Quantity {
+	var total resource.Quantity
+	for _, c := range metrics.Containers {
+		if q, ok := c.Usage[name]; ok {
+			total.Add(q)
+		}
+	}
+	return total
+}
+
+// podResources - the requests and limits of a pod, summed over its
+// containers. A resource only has a limit if every container sets one,
+// otherwise the pod can use as much of it as the node has.
+func podResources(pod corev1.Pod) (requests corev1.ResourceList, limits corev1.ResourceList) {
+	requests, limits = corev1.ResourceList{}, corev1.ResourceList{}
+	unlimited := map[corev1.ResourceName]bool{}
+	for _, c := range pod.Spec.Containers {
+		for name, q := range c.Resources.Requests {
+			total := requests[name]
+			total.Add(q)
+			requests[name] = total
+		}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			q, ok := c.Resources.Limits[name]
+			if !ok {
+				unlimited[name] = true
+				continue
+			}
+			total := limits[name]
+			total.Add(q)
+			limits[name] = total
+		}
+	}
+	for name := range unlimited {
+		delete(limits, name)
+	}
+	return requests, limits
+}
+
+// utilization - usage as a percentage of the quantity of name in of, or
+// <none> when of doesn't set it
+func utilization(usage resource.Quantity, of corev1.ResourceList, name corev1.ResourceName) string {
+	q, ok := of[name]
+	if !ok || q.IsZero() {
+		return "<none>"
+	}
+	return fmt.Sprintf("%d%%", usage.MilliValue()*100/q.MilliValue())
+}
+
+type GetTopPodsResponse struct {
+	Pod corev1.Pod
+	// Metrics is the usage of the pod, nil if it hasn't been measured yet
+	Metrics    *util.PodMetrics
+	StatusLine string
+	Match      Match
+}
@@ -0,0 +1,148 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetPersistentVolumeClaims - a public function for searching persistent volume claims
+// with keyword, matching on the claim name or its storage class name
+func GetPersistentVolumeClaims(opt *options.SearchOptions, keywords []string) ([]GetPersistentVolumeClaimsResponse, error) {
+	var pvcResponse []GetPersistentVolumeClaimsResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	pvcList, err := util.ClientFor(opt).PersistentVolumeClaimList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pvc := range pvcList.Items {
+		// return all claims under namespace if no keyword specific
+		match, ok := matcher.match(&pvc, pvcStorageClass(pvc))
+		if !ok {
+			continue
+		}
+		pvcInfo := GetPersistentVolumeClaimsResponse{
+			PersistentVolumeClaim: pvc,
+			StatusLine:            match.Highlight(NewPersistentVolumeClaimDetails(pvc)),
+			Match:                 match,
+		}
+		pvcResponse = append(pvcResponse, pvcInfo)
+	}
+	sortMatches(opt, pvcResponse, func(i int) Match { return pvcResponse[i].Match })
+	return pvcResponse, nil
+}
+
+// NewPersistentVolumeClaimDetails - render a persistent volume claim as a table row
+func NewPersistentVolumeClaimDetails(pvc corev1.PersistentVolumeClaim) string {
+	var capacity string
+	if q, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		capacity = q.String()
+	}
+
+	return fmt.Sprintf(util.PvcRowTemplate,
+		pvc.Namespace,
+		pvc.Name,
+		pvc.Status.Phase,
+		orNone(pvc.Spec.VolumeName),
+		orNone(capacity),
+		orNone(accessModes(pvc.Spec.AccessModes)),
+		orNone(pvcStorageClass(pvc)),
+		util.FormatAge(pvc.CreationTimestamp.Time))
+}
+
+func pvcStorageClass(pvc corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName != nil {
+		return *pvc.Spec.StorageClassName
+	}
+	return ""
+}
+
+type GetPersistentVolumeClaimsResponse struct {
+	PersistentVolumeClaim corev1.PersistentVolumeClaim
+	StatusLine            string
+	Match                 Match
+}
+
+// GetPersistentVolumes - a public function for searching persistent volumes with keyword,
+// matching on the volume name, its claim or its storage class name
+func GetPersistentVolumes(opt *options.SearchOptions, keywords []string) ([]GetPersistentVolumesResponse, error) {
+	var pvResponse []GetPersistentVolumesResponse
+	matcher, err := newMatcher(opt, keywords)
+	if err != nil {
+		return nil, err
+	}
+	pvList, err := util.ClientFor(opt).PersistentVolumeList(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pv := range pvList.Items {
+		// return all volumes if no keyword specific
+		match, ok := matcher.match(&pv, pvClaim(pv), pv.Spec.StorageClassName)
+		if !ok {
+			continue
+		}
+		pvInfo := GetPersistentVolumesResponse{
+			PersistentVolume: pv,
+			StatusLine:       match.Highlight(NewPersistentVolumeDetails(pv)),
+			Match:            match,
+		}
+		pvResponse = append(pvResponse, pvInfo)
+	}
+	sortMatches(opt, pvResponse, func(i int) Match { return pvResponse[i].Match })
+	return pvResponse, nil
+}
+
+// NewPersistentVolumeDetails - render a persistent volume as a table row
+func NewPersistentVolumeDetails(pv corev1.PersistentVolume) string {
+	var capacity string
+	if q, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		capacity = q.String()
+	}
+
+	return fmt.Sprintf(util.PvRowTemplate,
+		pv.Name,
+		orNone(capacity),
+		orNone(accessModes(pv.Spec.AccessModes)),
+		pv.Spec.PersistentVolumeReclaimPolicy,
+		pv.Status.Phase,
+		orNone(pvClaim(pv)),
+		orNone(pv.Spec.StorageClassName),
+		util.FormatAge(pv.CreationTimestamp.Time))
+}
+
+func pvClaim(pv corev1.PersistentVolume) string {
+	if pv.Spec.ClaimRef == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+}
+
+// accessModes - abbreviate access modes the way kubectl does, e.g. RWO,ROX
+func accessModes(modes []corev1.PersistentVolumeAccessMode) string {
+	var short []string
+	for _, m := range modes {
+		switch m {
+		case corev1.ReadWriteOnce:
+			short = append(short, "RWO")
+		case corev1.ReadOnlyMany:
+			short = append(short, "ROX")
+		case corev1.ReadWriteMany:
+			short = append(short, "RWX")
+		}
+	}
+	return strings.Join(short, ",")
+}
+
+type GetPersistentVolumesResponse struct {
+	PersistentVolume corev1.PersistentVolume
+	StatusLine       string
+	Match            Match
+}
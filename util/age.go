@@ -2,9 +2,52 @@ package util
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
+// AgeFormats - the values accepted by --age-format
+var AgeFormats = []string{"long", "short", "absolute"}
+
+// ageFormat - how FormatAge renders timestamps, see SetAgeFormat
+var ageFormat = "long"
+
+// SetAgeFormat - choose how ages are rendered in every table: long (3d4h),
+// short (3d) or absolute, the RFC3339 timestamp itself
+func SetAgeFormat(format string) error {
+	for _, f := range AgeFormats {
+		if f == format {
+			ageFormat = format
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --age-format %q, expected one of: %s", format, strings.Join(AgeFormats, "|"))
+}
+
+// FormatAge - render the age of something that happened at t in the format
+// chosen with SetAgeFormat
+func FormatAge(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	if ageFormat == "absolute" {
+		return t.Format(time.RFC3339)
+	}
+	return GetAge(time.Since(t))
+}
+
+// GetAge - return human readable time expression, short with --age-format=short
+func GetAge(d time.Duration) string {
+	if ageFormat == "short" {
+		return duration.ShortHumanDuration(d)
+	}
+	return duration.HumanDuration(d)
+}
+
 type Age struct {
 	Time time.Time
 }
@@ -23,3 +66,16 @@ func (a *Age) Relative() string {
 	}
 	return relativeAge
 }
+
+// days - a number of days in a duration, e.g. the 7d of 7d12h
+var days = regexp.MustCompile(`(\d+(?:\.\d+)?)d`)
+
+// ParseAge - parse a duration like time.ParseDuration does, also accepting
+// days as used in the AGE column, e.g. 7d or 1d12h
+func ParseAge(s string) (time.Duration, error) {
+	expanded := days.ReplaceAllStringFunc(s, func(d string) string {
+		n, _ := strconv.ParseFloat(strings.TrimSuffix(d, "d"), 64)
+		return strconv.FormatFloat(n*24, 'f', -1, 64) + "h"
+	})
+	return time.ParseDuration(expanded)
+}
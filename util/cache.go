@@ -0,0 +1,174 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	stdjson "encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	homedir "github.com/mitchellh/go-homedir"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/pkg/client"
+)
+
+// newCacheScope - identify the API server and kubeconfig identity the
+// clients of config talk to, so switching clusters or users never returns
+// another cluster's results
+func newCacheScope(config *rest.Config, opt *options.SearchOptions) string {
+	scope := []string{config.Host}
+	if raw, err := client.ClientConfig(ClientOptions(opt)).RawConfig(); err == nil {
+		name := opt.Context
+		if name == "" {
+			name = raw.CurrentContext
+		}
+		scope = append(scope, name)
+		if ctx, ok := raw.Contexts[name]; ok {
+			scope = append(scope, ctx.AuthInfo)
+		}
+	}
+	// an impersonated user sees what it may list, not what the kubeconfig's
+	// user may
+	if opt.As != "" {
+		scope = append(scope, opt.As, strings.Join(opt.AsGroups, ","))
+	}
+	return strings.Join(scope, "|")
+}
+
+// CacheDir - where list results are cached
+func CacheDir() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kk", "cache"), nil
+}
+
+// ClearCache - remove every cached list result
+func ClearCache() error {
+	dir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// cacheFile - the file caching the list `into` for the namespaces and
+// selectors in opt, or "" when caching is off
+func (c *Client) cacheFile(opt *options.SearchOptions, into runtime.Object) string {
+	if opt.CacheTTL <= 0 || opt.NoCache || c.cacheScope == "" {
+		return ""
+	}
+	kind := cacheKind(into)
+	if kind == "" {
+		return ""
+	}
+	dir, err := CacheDir()
+	if err != nil {
+		return ""
+	}
+
+	namespaces, o := c.SetOptions(opt)
+	key := fmt.Sprintf("%s\n%s\n%s\n%s\n%s", c.cacheScope, kind, strings.Join(namespaces, ","), o.LabelSelector, o.FieldSelector)
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// cacheKind - the kind of the list `into`, telling it apart from other cached
+// lists, or "" if it can't be cached. Secrets are never cached, their values
+// would be left in plain text on disk.
+func cacheKind(into runtime.Object) string {
+	if isSecretList(into) {
+		return ""
+	}
+	if kinds, _, err := scheme.Scheme.ObjectKinds(into); err == nil && len(kinds) > 0 {
+		return kinds[0].String()
+	}
+	// lists of the dynamic client are tagged with their resource by DynamicList
+	if list, ok := into.(*unstructured.UnstructuredList); ok && list.GetKind() != "" {
+		return list.GroupVersionKind().String()
+	}
+	return ""
+}
+
+// isSecretList - whether `into` is a list of secrets, typed or listed through
+// the dynamic client
+func isSecretList(into runtime.Object) bool {
+	switch list := into.(type) {
+	case *corev1.SecretList:
+		return true
+	case *unstructured.UnstructuredList:
+		gv, err := schema.ParseGroupVersion(list.GetAPIVersion())
+		return err == nil && gv.Group == "" && list.GetKind() == "secrets"
+	}
+	return false
+}
+
+// readCache - fill `into` from the cache if it holds a result younger than
+// --cache-ttl
+func (c *Client) readCache(opt *options.SearchOptions, into runtime.Object) bool {
+	file := c.cacheFile(opt, into)
+	if file == "" {
+		return false
+	}
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) > opt.CacheTTL {
+		return false
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	if err := stdjson.Unmarshal(data, into); err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Ignoring unreadable cache entry")
+		return false
+	}
+	log.WithFields(log.Fields{
+		"file": file,
+	}).Debug("Using cached list")
+	return true
+}
+
+// writeCache - store `into` for readCache. Failing to cache is not an error.
+func (c *Client) writeCache(opt *options.SearchOptions, into runtime.Object) {
+	file := c.cacheFile(opt, into)
+	if file == "" {
+		return
+	}
+	data, err := stdjson.Marshal(into)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(file), 0700)
+	}
+	if err == nil {
+		err = ioutil.WriteFile(file, data, 0600)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to cache list")
+	}
+}
+
+// setList - put items into `into` and cache the result
+func (c *Client) setList(opt *options.SearchOptions, into runtime.Object, items []runtime.Object) error {
+	if err := meta.SetList(into, items); err != nil {
+		return err
+	}
+	c.writeCache(opt, into)
+	return nil
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// dynamicList - an empty list of resource in apiVersion, tagged like
+// DynamicList does
+func dynamicList(apiVersion, resource string) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(apiVersion)
+	list.SetKind(resource)
+	return list
+}
+
+func TestCacheKindSkipsSecrets(t *testing.T) {
+	tests := []struct {
+		name   string
+		into   runtime.Object
+		cached bool
+	}{
+		{name: "pods", into: &corev1.PodList{}, cached: true},
+		{name: "configmaps", into: &corev1.ConfigMapList{}, cached: true},
+		{name: "secrets", into: &corev1.SecretList{}, cached: false},
+		{name: "dynamic secrets", into: dynamicList("v1", "secrets"), cached: false},
+		{name: "dynamic secrets of another group", into: dynamicList("example.com/v1", "secrets"), cached: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if kind := cacheKind(tt.into); (kind != "") != tt.cached {
+				t.Errorf("cacheKind() = %q, cached = %v, want %v", kind, kind != "", tt.cached)
+			}
+		})
+	}
+}
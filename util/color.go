@@ -61,3 +61,61 @@ func (cm *ColorManager) Colorize(s string) string {
 	col := color.New(colAttribute).Add(color.Bold).SprintfFunc()
 	return col(s)
 }
+
+// highlight - the color matched text is printed in
+var highlight = color.New(color.FgRed, color.Bold)
+
+// deleted - the color deleted objects are reported in
+var deleted = color.New(color.FgRed)
+
+// Deleted - color s as removed, when output is colored
+func Deleted(s string) string {
+	return deleted.Sprint(s)
+}
+
+// added and changed - the colors added and changed objects are reported in
+var (
+	added   = color.New(color.FgGreen)
+	changed = color.New(color.FgYellow)
+)
+
+// Added - color s as added, when output is colored
+func Added(s string) string {
+	return added.Sprint(s)
+}
+
+// Changed - color s as changed, when output is colored
+func Changed(s string) string {
+	return changed.Sprint(s)
+}
+
+// ColorEnabled - report whether output is colored. fatih/color turns color
+// off by itself when stdout isn't a terminal; --no-color turns it off always.
+func ColorEnabled() bool {
+	return !color.NoColor
+}
+
+// DisableColor - never color output, for --no-color
+func DisableColor() {
+	color.NoColor = true
+}
+
+// Highlight - color the byte ranges spans of s. spans must be in order and
+// not overlap.
+func Highlight(s string, spans [][2]int) string {
+	if !ColorEnabled() || len(spans) == 0 {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span[0] < last || span[1] > len(s) || span[0] >= span[1] {
+			continue
+		}
+		b.WriteString(s[last:span[0]])
+		b.WriteString(highlight.Sprint(s[span[0]:span[1]]))
+		last = span[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
@@ -0,0 +1,167 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Column - a table column whose values are extracted with a JSONPath expression
+type Column struct {
+	Header string
+	Path   *jsonpath.JSONPath
+}
+
+// ParseCustomColumns - parse a kubectl style column spec such as
+// NAME:.metadata.name,NODE:.spec.nodeName
+func ParseCustomColumns(spec string) ([]Column, error) {
+	return parseColumns(spec, ":", "custom-columns")
+}
+
+// ParseFieldColumns - parse a --columns spec such as
+// IP=.status.podIP,QOS=.status.qosClass
+func ParseFieldColumns(spec string) ([]Column, error) {
+	return parseColumns(spec, "=", "--columns")
+}
+
+func parseColumns(spec, sep, name string) ([]Column, error) {
+	var columns []Column
+	for _, part := range strings.Split(spec, ",") {
+		kv := strings.SplitN(part, sep, 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("unexpected %s spec: %q, expected <header>%s<json-path-expr>", name, part, sep)
+		}
+		path := jsonpath.New(kv[0]).AllowMissingKeys(true)
+		if err := path.Parse(relaxedJSONPath(kv[1])); err != nil {
+			return nil, fmt.Errorf("error parsing %s path %q: %v", name, kv[1], err)
+		}
+		columns = append(columns, Column{Header: kv[0], Path: path})
+	}
+	return columns, nil
+}
+
+// relaxedJSONPath - accept `.metadata.name` and `metadata.name` as well as
+// the full `{.metadata.name}` template form
+func relaxedJSONPath(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+// PrintCustomColumns - print one row per object with the value of every column
+func PrintCustomColumns(w io.Writer, columns []Column, objects []runtime.Object) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	if printHeaders {
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = c.Header
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+
+	for _, obj := range objects {
+		cells, err := ColumnValues(columns, obj)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// ColumnValues - evaluate every column against obj, rendering missing
+// fields as <none> like kubectl
+func ColumnValues(columns []Column, obj runtime.Object) ([]string, error) {
+	return columnValues(columns, obj, "<none>")
+}
+
+// FieldColumnHeader - the header of the --columns columns
+func FieldColumnHeader(columns []Column) string {
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	return strings.Join(headers, "\t")
+}
+
+// FieldColumnValues - evaluate every --columns column against obj, blank if
+// the field is missing like the --label-columns cells
+func FieldColumnValues(columns []Column, obj runtime.Object) (string, error) {
+	cells, err := columnValues(columns, obj, "")
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(cells, "\t"), nil
+}
+
+func columnValues(columns []Column, obj runtime.Object, missing string) ([]string, error) {
+	setKind(obj)
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		results, err := c.Path.FindResults(data)
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for _, r := range results {
+			for _, v := range r {
+				values = append(values, valueString(v))
+			}
+		}
+		if len(values) == 0 {
+			cells[i] = missing
+		} else {
+			cells[i] = strings.Join(values, ",")
+		}
+	}
+	return cells, nil
+}
+
+func valueString(v reflect.Value) string {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "<none>"
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// LabelColumnHeader - the header of the --label-columns columns: like kubectl
+// -L, the upper-cased label key without its prefix
+func LabelColumnHeader(keys []string) string {
+	var headers []string
+	for _, key := range keys {
+		headers = append(headers, strings.ToUpper(key[strings.LastIndex(key, "/")+1:]))
+	}
+	return strings.Join(headers, "\t")
+}
+
+// LabelColumnValues - the value of each label key on obj, blank if missing
+func LabelColumnValues(keys []string, obj runtime.Object) string {
+	var labels map[string]string
+	if accessor, err := meta.Accessor(obj); err == nil {
+		labels = accessor.GetLabels()
+	}
+	var values []string
+	for _, key := range keys {
+		values = append(values, labels[key])
+	}
+	return strings.Join(values, "\t")
+}
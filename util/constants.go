@@ -1,33 +1,110 @@
 package util
 
 const (
-	DaemonsetHeader       = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tUP-TO-DATE\tAVAILABLE\tNODE SELECTOR\tAGE"
-	DaemonsetHeaderWide   = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tUP-TO-DATE\tAVAILABLE\tNODE SELECTOR\tAGE\tCONTAINERS\tIMAGES\tSELECTOR"
+	DaemonsetHeader       = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tUP-TO-DATE\tAVAILABLE\tNODE SELECTOR\tAGE"
+	DaemonsetHeaderWide   = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tUP-TO-DATE\tAVAILABLE\tNODE SELECTOR\tAGE\tCONTAINERS\tIMAGES\tSELECTOR"
 	DeploymentHeader      = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tUP-TO-DATE\tAVAILABLE\tAGE"
-	DeploymentHeaderWide  = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tUP-TO-DATE\tAVAILABLE\tAGE\tCONTAINERS\tIMAGES"
+	DeploymentHeaderWide  = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tUP-TO-DATE\tAVAILABLE\tAGE\tCONTAINERS\tIMAGES\tSELECTOR"
 	HpaHeader             = "NAMESPACE\tNAME\tREFERENCE\tTARGETS\tMINPODS\tMAXPODS\tREPLICAS\tAGE"
 	NodeHeader            = "NAME\tSTATUS\tROLES\tAGE\tVERSION"
 	NodeHeaderWide        = "NAME\tSTATUS\tROLES\tAGE\tVERSION\tINTERNAL-IP\tEXTERNAL-IP\tOS-IMAGE\tKERNEL-VERSION\tCONTAINER-RUNTIME"
 	PodHeader             = "NAMESPACE\tNAME\tREADY\tSTATUS\tRESTART\tAGE"
-	PodHeaderWide         = "NAMESPACE\tNAME\tREADY\tSTATUS\tRESTART\tAGE\tIP\tNODENAME"
-	StatefulsetHeader     = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tAGE"
-	StatefulsetHeaderWide = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tAGE\tCONTAINERS\tIMAGES"
+	PodHeaderWide         = "NAMESPACE\tNAME\tREADY\tSTATUS\tRESTART\tAGE\tIP\tNODE\tNOMINATED NODE\tREADINESS GATES"
+	StatefulsetHeader     = "NAMESPACE\tNAME\tREADY\tCURRENT-REVISION\tUPDATE-REVISION\tAGE"
+	StatefulsetHeaderWide = "NAMESPACE\tNAME\tREADY\tCURRENT-REVISION\tUPDATE-REVISION\tAGE\tCONTAINERS\tIMAGES"
 	ConfigMapHeader       = "NAMESPACE\tNAME\tDATA\tAGE"
+	ConfigMapKeyHeader    = "NAMESPACE\tNAME\tKEY\tSIZE"
+	ConfigMapValueHeader  = "NAMESPACE\tNAME\tKEY\tVALUE"
 	SecretHeader          = "NAMESPACE\tNAME\tTYPE\tDATA\tAGE"
+	SecretKeyHeader       = "NAMESPACE\tNAME\tTYPE\tKEY\tSIZE"
+	SecretValueHeader     = "NAMESPACE\tNAME\tTYPE\tKEY\tVALUE"
 	ServiceHeader         = "NAMESPACE\tNAME\tREADY\tSTATUS\tRESTARTS\tAGE"
+	IngressHeader         = "NAMESPACE\tNAME\tHOSTS\tBACKENDS\tADDRESS\tPORTS\tAGE"
+	JobHeader             = "NAMESPACE\tNAME\tCOMPLETIONS\tSTATUS\tDURATION\tAGE"
+	CronJobHeader         = "NAMESPACE\tNAME\tSCHEDULE\tSUSPEND\tACTIVE\tLAST SCHEDULE\tAGE"
+	PvcHeader             = "NAMESPACE\tNAME\tSTATUS\tVOLUME\tCAPACITY\tACCESS MODES\tSTORAGECLASS\tAGE"
+	PvHeader              = "NAME\tCAPACITY\tACCESS MODES\tRECLAIM POLICY\tSTATUS\tCLAIM\tSTORAGECLASS\tAGE"
+	NamespaceHeader       = "NAME\tSTATUS\tAGE"
+	ReplicaSetHeader      = "NAMESPACE\tNAME\tOWNER\tDESIRED\tCURRENT\tREADY\tAGE"
+	ReplicaSetHeaderWide  = "NAMESPACE\tNAME\tOWNER\tDESIRED\tCURRENT\tREADY\tAGE\tCONTAINERS\tIMAGES\tSELECTOR"
+	RcHeader              = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tAGE"
+	RcHeaderWide          = "NAMESPACE\tNAME\tDESIRED\tCURRENT\tREADY\tAGE\tCONTAINERS\tIMAGES\tSELECTOR"
+	EventHeader           = "NAMESPACE\tLAST SEEN\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE"
+	StorageClassHeader    = "NAME\tPROVISIONER\tRECLAIMPOLICY\tVOLUMEBINDINGMODE\tDEFAULT\tAGE"
+	VaHeader              = "NAME\tATTACHER\tPV\tNODE\tATTACHED\tAGE"
+	ServiceAccountHeader  = "NAMESPACE\tNAME\tSECRETS\tPULL SECRETS\tAGE"
+	RoleHeader            = "NAMESPACE\tNAME\tRULES\tAGE"
+	ClusterRoleHeader     = "NAME\tRULES\tAGE"
+	RoleBindingHeader     = "NAMESPACE\tNAME\tROLE\tSUBJECTS\tAGE"
+	ClusterBindingHeader  = "NAME\tROLE\tSUBJECTS\tAGE"
+	ResourceHeader        = "NAMESPACE\tNAME\tAGE"
+	ClusterResourceHeader = "NAME\tAGE"
+	CrdHeader             = "NAME\tGROUP\tKIND\tSCOPE\tVERSIONS\tAGE"
+	NetworkPolicyHeader   = "NAMESPACE\tNAME\tPOD-SELECTOR\tPOLICY TYPES\tINGRESS RULES\tEGRESS RULES\tAGE"
+	EndpointsHeader       = "NAMESPACE\tNAME\tREADY\tNOT READY\tAGE"
+	PdbHeader             = "NAMESPACE\tNAME\tMIN AVAILABLE\tMAX UNAVAILABLE\tALLOWED DISRUPTIONS\tHEALTHY\tAGE"
+	QuotaHeader           = "NAMESPACE\tNAME\tUSED/HARD\tAGE"
+	LimitRangeHeader      = "NAMESPACE\tNAME\tTYPE\tDEFAULT REQUEST\tDEFAULT LIMIT\tMIN\tMAX\tAGE"
+	NodePodHeader         = "NODE\tNAMESPACE\tNAME\tREADY\tSTATUS\tRESTART\tAGE"
+	EndpointSliceHeader   = "NAMESPACE\tNAME\tSERVICE\tADDRESSTYPE\tPORTS\tREADY\tENDPOINTS\tAGE"
+	OwnerHeader           = "NAMESPACE\tNAME\tOWNERS\tROOT"
+	SvcHeader             = "NAMESPACE\tNAME\tTYPE\tCLUSTER-IP\tEXTERNAL-IP\tPORT(S)\tAGE"
+	TopPodHeader          = "NAMESPACE\tNAME\tCPU\tCPU/REQUEST\tCPU/LIMIT\tMEMORY\tMEMORY/REQUEST\tMEMORY/LIMIT"
+	RequestsHeader        = "NAMESPACE\tNAME\tCPU REQUESTS\tCPU LIMITS\tMEMORY REQUESTS\tMEMORY LIMITS"
+	NamespaceTotalsHeader = "NAMESPACE\tPODS\tCPU REQUESTS\tCPU LIMITS\tMEMORY REQUESTS\tMEMORY LIMITS"
+	DiffHeader            = "NAME\tCHANGE\tKEYS"
 
-	DaemonsetRowTemplate       = "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s"
-	DaemonsetRowTemplateWide   = "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s"
+	ImagesColumn      = "IMAGES"
+	MatchedLineColumn = "MATCHED LINE"
+	ContextColumn     = "CONTEXT"
+
+	DaemonsetRowTemplate       = "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s"
+	DaemonsetRowTemplateWide   = "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s"
 	DeploymentRowTemplate      = "%s\t%s\t%d\t%d\t%d\t%d\t%s"
-	DeploymentRowTemplateWide  = "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s"
-	HpaRowTemplate             = "%s\t%s\t%s/%s\t%d%%/%d%%\t%d\t%d\t%d\t%s"
+	DeploymentRowTemplateWide  = "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s"
+	HpaRowTemplate             = "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s"
 	NodeRowTemplate            = "%s\t%s\t%s\t%s\t%s"
 	NodeRowTemplateWide        = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s"
 	PodRowTemplate             = "%s\t%s\t%d/%d\t%s\t%d\t%s"
-	PodRowTemplateWide         = "%s\t%s\t%d/%d\t%s\t%d\t%s\t%s\t%s"
-	StatefulsetRowTemplate     = "%s\t%s\t%d\t%d\t%s"
-	StatefulsetRowTemplateWide = "%s\t%s\t%d\t%d\t%s\t%s\t%s"
+	PodRowTemplateWide         = "%s\t%s\t%d/%d\t%s\t%d\t%s\t%s\t%s\t%s\t%s"
+	StatefulsetRowTemplate     = "%s\t%s\t%d/%d\t%s\t%s\t%s"
+	StatefulsetRowTemplateWide = "%s\t%s\t%d/%d\t%s\t%s\t%s\t%s\t%s"
 	ConfigMapRowTemplate       = "%s\t%s\t%d\t%s"
+	ConfigMapKeyRowTemplate    = "%s\t%s\t%s\t%s"
 	SecretRowTemplate          = "%s\t%s\t%s\t%d\t%s"
+	SecretKeyRowTemplate       = "%s\t%s\t%s\t%s\t%s"
 	ServiceRowTemplate         = "%s\t%s\t%d/%d\t%s\t%d\t%s"
+	IngressRowTemplate         = "%s\t%s\t%s\t%s\t%s\t%s\t%s"
+	JobRowTemplate             = "%s\t%s\t%d/%d\t%s\t%s\t%s"
+	CronJobRowTemplate         = "%s\t%s\t%s\t%t\t%d\t%s\t%s"
+	PvcRowTemplate             = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s"
+	PvRowTemplate              = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s"
+	NamespaceRowTemplate       = "%s\t%s\t%s"
+	ReplicaSetRowTemplate      = "%s\t%s\t%s\t%d\t%d\t%d\t%s"
+	ReplicaSetRowTemplateWide  = "%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s"
+	RcRowTemplate              = "%s\t%s\t%d\t%d\t%d\t%s"
+	RcRowTemplateWide          = "%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s"
+	EventRowTemplate           = "%s\t%s\t%s\t%s\t%s\t%d\t%s"
+	StorageClassRowTemplate    = "%s\t%s\t%s\t%s\t%t\t%s"
+	VaRowTemplate              = "%s\t%s\t%s\t%s\t%t\t%s"
+	ServiceAccountRowTemplate  = "%s\t%s\t%d\t%d\t%s"
+	RoleRowTemplate            = "%s\t%s\t%d\t%s"
+	ClusterRoleRowTemplate     = "%s\t%d\t%s"
+	RoleBindingRowTemplate     = "%s\t%s\t%s\t%s\t%s"
+	ClusterBindingRowTemplate  = "%s\t%s\t%s\t%s"
+	ResourceRowTemplate        = "%s\t%s\t%s"
+	ClusterResourceRowTemplate = "%s\t%s"
+	CrdRowTemplate             = "%s\t%s\t%s\t%s\t%s\t%s"
+	NetworkPolicyRowTemplate   = "%s\t%s\t%s\t%s\t%d\t%d\t%s"
+	PdbRowTemplate             = "%s\t%s\t%s\t%s\t%d\t%d/%d\t%s"
+	QuotaRowTemplate           = "%s\t%s\t%s\t%s"
+	LimitRangeRowTemplate      = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s"
+	EndpointsRowTemplate       = "%s\t%s\t%s\t%s\t%s"
+	EndpointSliceRowTemplate   = "%s\t%s\t%s\t%s\t%s\t%d/%d\t%s\t%s"
+	OwnerRowTemplate           = "%s\t%s\t%s\t%s"
+	SvcRowTemplate             = "%s\t%s\t%s\t%s\t%s\t%s\t%s"
+	TopPodRowTemplate          = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s"
+	RequestsRowTemplate        = "%s\t%s\t%s\t%s\t%s\t%s"
+	NamespaceTotalsRowTemplate = "%s\t%d\t%s\t%s\t%s\t%s"
+	DiffRowTemplate            = "%s\t%s\t%s"
 )
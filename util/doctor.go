@@ -0,0 +1,49 @@
+package util
+
+import (
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ServerVersion - the version of the API server, which also tells it can be
+// reached with the credentials of the kubeconfig
+func (c *Client) ServerVersion() (string, error) {
+	version, err := c.Clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.GitVersion, nil
+}
+
+// CanList - ask the API server whether the user may list resource of group
+// in namespace, or in every namespace when it is empty. reason is the
+// authorizer's explanation of a denial, if it gave one.
+func (c *Client) CanList(namespace, group, resource string) (allowed bool, reason string, err error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     group,
+				Resource:  resource,
+			},
+		},
+	}
+	result, err := c.Clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(review)
+	if err != nil {
+		return false, "", fmt.Errorf("unable to review access: %v", err)
+	}
+	return result.Status.Allowed, result.Status.Reason, nil
+}
+
+// HasMetricsAPI - report whether the cluster serves the metrics API that
+// kk top reads, which metrics-server provides
+func (c *Client) HasMetricsAPI() (bool, error) {
+	_, err := c.Clientset.Discovery().ServerResourcesForGroupVersion(podMetricsResource.GroupVersion().String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
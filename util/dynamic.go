@@ -0,0 +1,246 @@
+package util
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	discoveryv1alpha1 "k8s.io/api/discovery/v1alpha1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/pkg/client"
+)
+
+// crdResources - CustomResourceDefinitions are served as v1 from Kubernetes
+// 1.16 and as v1beta1 before that
+var crdResources = []schema.GroupVersionResource{
+	{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+	{Group: "apiextensions.k8s.io", Version: "v1beta1", Resource: "customresourcedefinitions"},
+}
+
+func (c *Client) getDynamicClient() (dynamic.Interface, error) {
+	c.lazy.dynamicOnce.Do(func() {
+		if c.Config == nil {
+			c.lazy.dynamicErr = fmt.Errorf("no dynamic client to list with")
+			return
+		}
+		c.lazy.dynamic, c.lazy.dynamicErr = client.InitDynamicClient(c.Config)
+	})
+	return c.lazy.dynamic, c.lazy.dynamicErr
+}
+
+func (c *Client) getRESTMapper() meta.RESTMapper {
+	c.lazy.mapperOnce.Do(func() {
+		discovery := memory.NewMemCacheClient(c.Clientset.Discovery())
+		// the shortcut expander resolves short names such as "cm" like kubectl
+		c.lazy.mapper = restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(discovery), discovery)
+	})
+	return c.lazy.mapper
+}
+
+// ResolveResource - find the resource named by arg the way kubectl does:
+// a plural, singular or short name, optionally qualified with its group and
+// version, e.g. certificates.cert-manager.io or widgets.v1.example.com.
+// namespaced reports whether the resource lives in namespaces.
+func (c *Client) ResolveResource(arg string) (gvr schema.GroupVersionResource, namespaced bool, err error) {
+	mapper := c.getRESTMapper()
+
+	fullySpecified, groupResource := schema.ParseResourceArg(arg)
+	gvr, err = schema.GroupVersionResource{}, fmt.Errorf("the server doesn't have a resource type %q", arg)
+	if fullySpecified != nil {
+		if found, e := mapper.ResourceFor(*fullySpecified); e == nil {
+			gvr, err = found, nil
+		}
+	}
+	if err != nil {
+		if found, e := mapper.ResourceFor(groupResource.WithVersion("")); e == nil {
+			gvr, err = found, nil
+		}
+	}
+	if err != nil {
+		return gvr, false, err
+	}
+
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return gvr, false, err
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return gvr, false, err
+	}
+	return gvr, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// DynamicList - return a list of any resource through the dynamic client
+func (c *Client) DynamicList(opt *options.SearchOptions, gvr schema.GroupVersionResource, namespaced bool) (*unstructured.UnstructuredList, error) {
+	dc, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	list := &unstructured.UnstructuredList{}
+	// the list kind is only known after discovery; the resource is enough to
+	// tell cached lists apart, and the cache needs a kind to encode the list
+	list.SetAPIVersion(gvr.GroupVersion().String())
+	list.SetKind(gvr.Resource)
+	if namespaced {
+		err = c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+			return dc.Resource(gvr).Namespace(ns).List(o)
+		})
+	} else {
+		err = c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+			return dc.Resource(gvr).List(o)
+		})
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":      err.Error(),
+			"resource": gvr.String(),
+		}).Debug("Unable to get dynamic List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// CustomResourceDefinitionList - return a list of CustomResourceDefinition(s),
+// falling back to apiextensions.k8s.io/v1beta1 on older clusters
+func (c *Client) CustomResourceDefinitionList(opt *options.SearchOptions) (*unstructured.UnstructuredList, error) {
+	var list *unstructured.UnstructuredList
+	var err error
+	for _, gvr := range crdResources {
+		list, err = c.DynamicList(opt, gvr, false)
+		if !apierrors.IsNotFound(err) {
+			break
+		}
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get CustomResourceDefinition List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// endpointSliceResources - EndpointSlices went from discovery.k8s.io/v1alpha1,
+// the only version the typed client knows, to v1beta1 and v1
+var endpointSliceResources = []schema.GroupVersionResource{
+	{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"},
+	{Group: "discovery.k8s.io", Version: "v1beta1", Resource: "endpointslices"},
+	{Group: "discovery.k8s.io", Version: "v1alpha1", Resource: "endpointslices"},
+}
+
+// EndpointSliceList - return a list of EndpointSlice(s) from the newest version
+// the cluster serves, converted into the v1alpha1 type. The fields kk reads,
+// addresses, ready conditions and ports, are the same in every version.
+func (c *Client) EndpointSliceList(opt *options.SearchOptions) (*discoveryv1alpha1.EndpointSliceList, error) {
+	var unstructuredList *unstructured.UnstructuredList
+	var err error
+	for _, gvr := range endpointSliceResources {
+		unstructuredList, err = c.DynamicList(opt, gvr, true)
+		if !apierrors.IsNotFound(err) {
+			break
+		}
+	}
+	if err == nil {
+		list := &discoveryv1alpha1.EndpointSliceList{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredList.UnstructuredContent(), list); err == nil {
+			return list, nil
+		}
+	}
+	log.WithFields(log.Fields{
+		"err": err.Error(),
+	}).Debug("Unable to get EndpointSlice List")
+	return nil, err
+}
+
+// podDisruptionBudgetResources - policy/v1 replaced v1beta1, the only version
+// the typed client knows, which was removed in Kubernetes 1.25
+var podDisruptionBudgetResources = []schema.GroupVersionResource{
+	{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+	{Group: "policy", Version: "v1beta1", Resource: "poddisruptionbudgets"},
+}
+
+// PodDisruptionBudgetList - return a list of PodDisruptionBudget(s) from the
+// newest version the cluster serves, converted into the v1beta1 type, which
+// has the same fields
+func (c *Client) PodDisruptionBudgetList(opt *options.SearchOptions) (*policyv1beta1.PodDisruptionBudgetList, error) {
+	var unstructuredList *unstructured.UnstructuredList
+	var err error
+	for _, gvr := range podDisruptionBudgetResources {
+		unstructuredList, err = c.DynamicList(opt, gvr, true)
+		if !apierrors.IsNotFound(err) {
+			break
+		}
+	}
+	if err == nil {
+		list := &policyv1beta1.PodDisruptionBudgetList{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredList.UnstructuredContent(), list); err == nil {
+			return list, nil
+		}
+	}
+	log.WithFields(log.Fields{
+		"err": err.Error(),
+	}).Debug("Unable to get PodDisruptionBudget List")
+	return nil, err
+}
+
+// dynamicWatcher - watch a resource resolved with ResolveResource
+func (c *Client) dynamicWatcher(resource string) (watcher, error) {
+	gvr, namespaced, err := c.ResolveResource(resource)
+	if err != nil {
+		return watcher{}, err
+	}
+	dc, err := c.getDynamicClient()
+	if err != nil {
+		return watcher{}, err
+	}
+	return watcher{namespaced, func(_ kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		if namespaced {
+			return dc.Resource(gvr).Namespace(ns).Watch(o)
+		}
+		return dc.Resource(gvr).Watch(o)
+	}}, nil
+}
+
+// resourceVersionFunc - the resource version of a resource in namespace ns
+// as of now
+type resourceVersionFunc func(ns string, o metav1.ListOptions) (string, error)
+
+// resourceVersion - list resource through the dynamic client for its
+// resource version, one item at a time since only the version is needed
+func (c *Client) resourceVersion(resource string) (resourceVersionFunc, error) {
+	gvr, namespaced, err := c.ResolveResource(resource)
+	if err != nil {
+		return nil, err
+	}
+	dc, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return func(ns string, o metav1.ListOptions) (string, error) {
+		o.Limit = 1
+		var list *unstructured.UnstructuredList
+		var err error
+		if namespaced {
+			list, err = dc.Resource(gvr).Namespace(ns).List(o)
+		} else {
+			list, err = dc.Resource(gvr).List(o)
+		}
+		if err != nil {
+			return "", err
+		}
+		return list.GetResourceVersion(), nil
+	}, nil
+}
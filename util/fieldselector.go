@@ -0,0 +1,98 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// commonSelectableFields - fields every resource can be selected by
+var commonSelectableFields = []string{"metadata.name", "metadata.namespace"}
+
+// selectableFields - the extra fields the API server supports in a field
+// selector for each resource. Resources missing from the map are passed
+// through unchecked.
+var selectableFields = map[string][]string{
+	"pods": {"spec.nodeName", "spec.restartPolicy", "spec.schedulerName", "spec.serviceAccountName",
+		"status.phase", "status.podIP", "status.nominatedNodeName"},
+	"events": {"involvedObject.kind", "involvedObject.namespace", "involvedObject.name", "involvedObject.uid",
+		"involvedObject.apiVersion", "involvedObject.resourceVersion", "involvedObject.fieldPath",
+		"reason", "source", "type"},
+	"namespaces":               {"status.phase"},
+	"deployments":              {},
+	"daemonsets":               {},
+	"statefulsets":             {},
+	"replicasets":              {"status.replicas"},
+	"replicationcontrollers":   {"status.replicas"},
+	"jobs":                     {"status.successful"},
+	"cronjobs":                 {},
+	"services":                 {},
+	"ingresses":                {},
+	"persistentvolumeclaims":   {},
+	"persistentvolumes":        {},
+	"horizontalpodautoscalers": {},
+	"storageclasses":           {},
+	"volumeattachments":        {},
+	"serviceaccounts":          {},
+	"configmaps":               {},
+	"secrets":                  {"type"},
+	"roles":                    {},
+	"rolebindings":             {},
+	"clusterroles":             {},
+	"clusterrolebindings":      {},
+	"networkpolicies":          {},
+	"endpoints":                {},
+	"nodes":                    {"spec.unschedulable"},
+	"resourcequotas":           {},
+	"limitranges":              {},
+}
+
+// selectableValues - fields that only take a fixed set of values, so a typo is
+// caught here rather than silently matching nothing
+var selectableValues = map[string][]string{
+	"pods/status.phase":       {"Pending", "Running", "Succeeded", "Failed", "Unknown"},
+	"pods/spec.restartPolicy": {"Always", "OnFailure", "Never"},
+	"namespaces/status.phase": {"Active", "Terminating"},
+	"events/type":             {"Normal", "Warning"},
+}
+
+// ValidateFieldSelector - check selector against the fields resource can be
+// selected by before it is sent to the API server
+func ValidateFieldSelector(resource string, selector string) error {
+	if selector == "" {
+		return nil
+	}
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return fmt.Errorf("invalid --field-selector %q: %v", selector, err)
+	}
+	supported, known := selectableFields[resource]
+	if !known {
+		return nil
+	}
+	supported = append(append([]string{}, commonSelectableFields...), supported...)
+
+	for _, req := range parsed.Requirements() {
+		if !contains(supported, req.Field) {
+			sort.Strings(supported)
+			return fmt.Errorf("invalid --field-selector %q: %s does not support field %q, supported fields are: %s",
+				selector, resource, req.Field, strings.Join(supported, ", "))
+		}
+		if values, ok := selectableValues[resource+"/"+req.Field]; ok && !contains(values, req.Value) {
+			return fmt.Errorf("invalid --field-selector %q: %q is not a valid value for %s, expected one of: %s",
+				selector, req.Value, req.Field, strings.Join(values, ", "))
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package util
+
+// FuzzyMatch - report whether every rune of query appears in candidate in the
+// same order, e.g. "frntnd" in "frontend-deployment". Matches score higher
+// when they are consecutive or start a new word, so better matches can be
+// ranked first. The comparison is case-sensitive.
+func FuzzyMatch(query, candidate string) (score int, ok bool) {
+	score, _, ok = fuzzyMatch(query, candidate)
+	return score, ok
+}
+
+// FuzzySpans - the byte ranges of candidate matched by query, as found by
+// FuzzyMatch, with consecutive runes merged into one range
+func FuzzySpans(query, candidate string) [][2]int {
+	_, spans, _ := fuzzyMatch(query, candidate)
+	return spans
+}
+
+func fuzzyMatch(query, candidate string) (score int, spans [][2]int, ok bool) {
+	q := []rune(query)
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+
+	qi, prev := 0, -2
+	var last rune
+	ci := 0
+	for offset, r := range candidate {
+		if qi == len(q) {
+			break
+		}
+		if r == q[qi] {
+			score++
+			if ci == prev+1 {
+				score += 5
+				spans[len(spans)-1][1] = offset + len(string(r))
+			} else {
+				spans = append(spans, [2]int{offset, offset + len(string(r))})
+			}
+			if ci == 0 || isWordSeparator(last) {
+				score += 3
+			}
+			prev = ci
+			qi++
+		}
+		last = r
+		ci++
+	}
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	return score, spans, true
+}
+
+func isWordSeparator(r rune) bool {
+	switch r {
+	case '-', '_', '.', '/', ':':
+		return true
+	}
+	return false
+}
+
+// Levenshtein - the number of single rune insertions, deletions and
+// substitutions turning a into b
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, minInt(cur[j-1]+1, prev[j-1]+cost))
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// ClosestMatch - the candidate nearest to s by Levenshtein distance, if it is
+// close enough to be a typo of s: at most a third of its length away, or 2
+func ClosestMatch(s string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		if d := Levenshtein(s, c); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	limit := len([]rune(s)) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDistance < 0 || bestDistance > limit {
+		return "", false
+	}
+	return best, true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
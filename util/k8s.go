@@ -1,47 +1,249 @@
 package util
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
 	"os/exec"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/duration"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/mateo1647/kk/internal/options"
 	"github.com/mateo1647/kk/pkg/client"
 )
 
+// Client - the clients and settings of the kubeconfig context a search goes
+// through. InitClient builds it from the kubeconfig, SetClient swaps it, e.g.
+// for a clientset from k8s.io/client-go/kubernetes/fake.
+type Client struct {
+	Clientset kubernetes.Interface
+	// Dynamic - built from Config when first needed if nil
+	Dynamic dynamic.Interface
+	// Config - the rest config the clients were built from, nil when the
+	// clientset wasn't built from a kubeconfig
+	Config *rest.Config
+	// Namespace - the default namespace of the context, searched when no
+	// namespace is given; "default" when empty
+	Namespace string
+
+	// kubectlFlags - the flags kk connects to the cluster with that kubectl
+	// has to be run with as well
+	kubectlFlags []string
+	// cacheScope - the cluster and user lists are cached under, caching is
+	// off when empty, see newCacheScope
+	cacheScope string
+
+	lazy *lazyClients
+}
+
+// lazyClients - the dynamic client, REST mapper and table client are only
+// built when a search needs them, discovery costs a round trip per API group
+type lazyClients struct {
+	dynamicOnce sync.Once
+	dynamic     dynamic.Interface
+	dynamicErr  error
+
+	mapperOnce sync.Once
+	mapper     meta.RESTMapper
+
+	tableOnce sync.Once
+	table     rest.Interface
+	tableErr  error
+}
+
 var (
-	clientset = client.InitClient()
+	clientsMu sync.RWMutex
+	// defaultClient - the client of the context searched
+	defaultClient = &Client{lazy: &lazyClients{}}
+	// contextClients - the clients of the contexts searched at once with
+	// --contexts, by context name
+	contextClients = map[string]*Client{}
 )
 
-// setOptions - set common options for clientset
-func SetOptions(opt *options.SearchOptions) (string, *metav1.ListOptions) {
+// NewClient - build the clients for the kubeconfig context selected in opt
+// and resolve its default namespace
+func NewClient(opt *options.SearchOptions) (*Client, error) {
+	clientOptions := ClientOptions(opt)
+	config, err := client.Config(clientOptions)
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := client.InitClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Clientset:    clientset,
+		Config:       config,
+		Namespace:    contextNamespace(clientOptions),
+		kubectlFlags: connectionFlags(opt),
+		cacheScope:   newCacheScope(config, opt),
+		lazy:         &lazyClients{},
+	}, nil
+}
+
+// InitClient - build the client for the kubeconfig context selected in opt,
+// once the command line has been parsed, and search through it from now on
+func InitClient(opt *options.SearchOptions) error {
+	c, err := NewClient(opt)
+	if err != nil {
+		return err
+	}
+	SetClient(*c)
+	return nil
+}
+
+// InitContextClient - build the client for the context of opt, one of the
+// several searched at once with --contexts
+func InitContextClient(opt *options.SearchOptions) error {
+	c, err := NewClient(opt)
+	if err != nil {
+		return err
+	}
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	contextClients[opt.Context] = c
+	return nil
+}
+
+// SetClient - search through c from now on instead of the clients built
+// from the kubeconfig
+func SetClient(c Client) {
+	// resources are discovered and resolved again through the new clients
+	c.lazy = &lazyClients{}
+	if c.Dynamic != nil {
+		c.lazy.dynamicOnce.Do(func() {
+			c.lazy.dynamic = c.Dynamic
+		})
+	}
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	defaultClient = &c
+	contextClients = map[string]*Client{}
+}
+
+// ClientFor - the client of the context opt searches
+func ClientFor(opt *options.SearchOptions) *Client {
+	return clientForContext(opt.Context)
+}
+
+// clientForContext - the client of a context searched with --contexts, or
+// the client of the context searched
+func clientForContext(context string) *Client {
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+	if c, ok := contextClients[context]; ok {
+		return c
+	}
+	return defaultClient
+}
+
+// contextNamespace - the default namespace of the context, or of the pod kk
+// runs in, "" if it can't be resolved
+func contextNamespace(opt client.Options) string {
+	ns, err := client.Namespace(opt)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Failed to resolve namespace")
+		return ""
+	}
+	return ns
+}
+
+// connectionFlags - the kubectl flags for the connection settings of opt
+// that aren't in the kubeconfig
+func connectionFlags(opt *options.SearchOptions) []string {
+	var flags []string
+	if opt.InsecureSkipTLSVerify {
+		flags = append(flags, "--insecure-skip-tls-verify")
+	}
+	if opt.CertificateAuthority != "" {
+		flags = append(flags, "--certificate-authority="+opt.CertificateAuthority)
+	}
+	if opt.As != "" {
+		flags = append(flags, "--as="+opt.As)
+	}
+	for _, group := range opt.AsGroups {
+		flags = append(flags, "--as-group="+group)
+	}
+	return flags
+}
+
+// ClientOptions - the connection settings held in opt
+func ClientOptions(opt *options.SearchOptions) client.Options {
+	return client.Options{
+		Kubeconfig: opt.Kubeconfig,
+		Context:    opt.Context,
+		InCluster:  opt.InCluster,
+		QPS:        opt.QPS,
+		Burst:      opt.Burst,
+		Timeout:    opt.Timeout,
+
+		InsecureSkipTLSVerify: opt.InsecureSkipTLSVerify,
+		CertificateAuthority:  opt.CertificateAuthority,
+		As:                    opt.As,
+		AsGroups:              opt.AsGroups,
+	}
+}
+
+// podNamespaceEnv - the env var pods conventionally get their namespace in
+const podNamespaceEnv = "POD_NAMESPACE"
+
+// podNamespace - the namespace in $POD_NAMESPACE, "" if it isn't set
+func podNamespace() string {
+	return strings.TrimSpace(os.Getenv(podNamespaceEnv))
+}
+
+// SetOptions - the namespaces to search and the list options for opt. Without
+// -n or -A the namespace of the pod kk runs in is searched, then the
+// namespaces of the config file, then the default namespace of the context.
+func (c *Client) SetOptions(opt *options.SearchOptions) ([]string, *metav1.ListOptions) {
 	// set default namespace as "default"
-	namespace := "default"
+	namespaces := []string{"default"}
 
-	// override `namespace` if `--all-namespaces` exist
+	// override `namespaces` if `--all-namespaces` exist
 	if opt.AllNamespaces {
-		namespace = ""
+		namespaces = []string{""}
 	} else {
-		if len(opt.Namespace) > 0 {
-			namespace = opt.Namespace
-		} else {
-			ns, _, err := client.ClientConfig().Namespace()
-			if err != nil {
-				log.WithFields(log.Fields{
-					"err": err.Error(),
-				}).Debug("Failed to resolve namespace")
-			} else {
-				namespace = ns
-			}
+		if requested := requestedNamespaces(opt.Namespaces); len(requested) > 0 {
+			namespaces = requested
+		} else if ns := podNamespace(); ns != "" {
+			// injected with the downward API when kk runs in a pod
+			namespaces = []string{ns}
+		} else if defaults := requestedNamespaces(opt.DefaultNamespaces); len(defaults) > 0 {
+			namespaces = defaults
+		} else if c.Namespace != "" {
+			// the default namespace of the selected context, or of the pod kk runs in
+			namespaces = []string{c.Namespace}
 		}
 	}
 
@@ -50,160 +252,1007 @@ func SetOptions(opt *options.SearchOptions) (string, *metav1.ListOptions) {
 		LabelSelector: opt.Selector,
 		FieldSelector: opt.FieldSelector,
 	}
-	return namespace, listOptions
+	if opt.ChunkSize > 0 {
+		listOptions.Limit = opt.ChunkSize
+	}
+	if opt.Timeout > 0 {
+		// the server rounds down, so round up to keep a sub-second timeout
+		seconds := int64(math.Ceil(opt.Timeout.Seconds()))
+		listOptions.TimeoutSeconds = &seconds
+	}
+	return namespaces, listOptions
+}
+
+// requestedNamespaces - trim and de-duplicate the namespaces given on the command line
+func requestedNamespaces(input []string) []string {
+	seen := map[string]bool{}
+	var namespaces []string
+	for _, ns := range input {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// listFunc - list a single page of a resource in namespace ns
+type listFunc func(ns string, o metav1.ListOptions) (runtime.Object, error)
+
+// listNamespaced - call list once for every namespace resolved from opt and
+// merge the items of each result into the typed list `into`. When several
+// namespaces were requested, the ones the user isn't allowed to read are
+// skipped instead of failing the whole search.
+//
+// With opt.Concurrency above 1 the calls run on a bounded pool of workers,
+// and `--all-namespaces` is fanned out into one call per namespace. Results
+// are merged in namespace order so the output is the same as a serial run.
+func (c *Client) listNamespaced(opt *options.SearchOptions, into runtime.Object, list listFunc) error {
+	if c.readCache(opt, into) {
+		return nil
+	}
+	list = withRetries(opt.Retries, list)
+	namespaces, o := c.SetOptions(opt)
+
+	workers := opt.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if opt.AllNamespaces && workers > 1 {
+		all, err := c.namespaceNames()
+		if err != nil {
+			log.WithFields(log.Fields{
+				"err": err.Error(),
+			}).Debug("Unable to fan out across namespaces, listing serially")
+		} else {
+			namespaces = all
+		}
+	}
+
+	results := make([][]runtime.Object, len(namespaces))
+	errs := make([]error, len(namespaces))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	for i, ns := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, ns string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i], errs[i] = listAllPages(list, ns, *o)
+		}(i, ns)
+	}
+	wg.Wait()
+
+	// the same object can only be returned twice by overlapping requests,
+	// so de-duplicate on UID while keeping the first occurrence
+	seen := map[types.UID]bool{}
+	var items []runtime.Object
+	for i, ns := range namespaces {
+		if err := errs[i]; err != nil {
+			if len(namespaces) > 1 && apierrors.IsForbidden(err) {
+				log.WithFields(log.Fields{
+					"namespace": ns,
+					"err":       err.Error(),
+				}).Debug("Skipping namespace")
+				continue
+			}
+			return err
+		}
+		for _, obj := range results[i] {
+			if accessor, err := meta.Accessor(obj); err == nil && accessor.GetUID() != "" {
+				if seen[accessor.GetUID()] {
+					continue
+				}
+				seen[accessor.GetUID()] = true
+			}
+			items = append(items, obj)
+		}
+	}
+	return c.setList(opt, into, items)
+}
+
+// listClusterScoped - fetch every page of a cluster-scoped resource into the typed list `into`
+func (c *Client) listClusterScoped(opt *options.SearchOptions, into runtime.Object, list listFunc) error {
+	if c.readCache(opt, into) {
+		return nil
+	}
+	list = withRetries(opt.Retries, list)
+	_, o := c.SetOptions(opt)
+	items, err := listAllPages(list, "", *o)
+	if err != nil {
+		return err
+	}
+	return c.setList(opt, into, items)
+}
+
+// listAllPages - follow the continue token until every page has been fetched.
+// Pages are o.Limit items long, an unset limit fetches everything at once.
+func listAllPages(list listFunc, ns string, o metav1.ListOptions) ([]runtime.Object, error) {
+	var items []runtime.Object
+	for {
+		result, err := list(ns, o)
+		if err != nil {
+			if o.Continue != "" && apierrors.IsResourceExpired(err) {
+				// the snapshot we were paging through is gone, fall back
+				// to a single unpaginated request like kubectl does
+				log.WithFields(log.Fields{
+					"namespace": ns,
+				}).Debug("Continue token expired, listing without pagination")
+				o.Limit, o.Continue = 0, ""
+				items = nil
+				continue
+			}
+			return nil, err
+		}
+
+		objects, err := meta.ExtractList(result)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, objects...)
+
+		listMeta, err := meta.ListAccessor(result)
+		if err != nil {
+			return nil, err
+		}
+		if listMeta.GetContinue() == "" {
+			return items, nil
+		}
+		o.Continue = listMeta.GetContinue()
+	}
+}
+
+// namespaceNames - return the name of every namespace in the cluster
+func (c *Client) namespaceNames() ([]string, error) {
+	list, err := c.Clientset.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// CheckNamespaces - fail on a namespace asked for with -n that doesn't
+// exist, suggesting the closest existing one, rather than searching it and
+// finding nothing. With --fuzzy-namespace the namespace is replaced by the
+// one it matches instead. Namespaces the user may not get are assumed to
+// exist. The namespaces of the config file are checked the same way when
+// they are the ones searched.
+func (c *Client) CheckNamespaces(opt *options.SearchOptions) error {
+	if opt.AllNamespaces {
+		return nil
+	}
+	var err error
+	if len(requestedNamespaces(opt.Namespaces)) == 0 && podNamespace() == "" {
+		opt.DefaultNamespaces, err = c.checkNamespaces(opt, opt.DefaultNamespaces)
+		return err
+	}
+	opt.Namespaces, err = c.checkNamespaces(opt, opt.Namespaces)
+	return err
+}
+
+// checkNamespaces - the namespaces, each checked to exist or resolved with
+// --fuzzy-namespace
+func (c *Client) checkNamespaces(opt *options.SearchOptions, input []string) ([]string, error) {
+	namespaces := requestedNamespaces(input)
+	for i, ns := range namespaces {
+		_, err := c.Clientset.CoreV1().Namespaces().Get(ns, metav1.GetOptions{})
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			log.WithFields(log.Fields{
+				"err":       err.Error(),
+				"namespace": ns,
+			}).Debug("Unable to check namespace exists")
+			continue
+		}
+		names, err := c.namespaceNames()
+		if err != nil {
+			return nil, fmt.Errorf("namespace %q not found", ns)
+		}
+		if opt.FuzzyNamespace {
+			resolved, err := fuzzyNamespace(ns, names)
+			if err != nil {
+				return nil, err
+			}
+			log.WithFields(log.Fields{
+				"namespace": ns,
+				"resolved":  resolved,
+			}).Info("Using the closest namespace")
+			namespaces[i] = resolved
+			continue
+		}
+		if closest, ok := ClosestMatch(ns, names); ok {
+			return nil, fmt.Errorf("namespace %q not found, did you mean %q?", ns, closest)
+		}
+		return nil, fmt.Errorf("namespace %q not found", ns)
+	}
+	return namespaces, nil
+}
+
+// fuzzyNamespace - the namespace of names that ns is a subsequence of with
+// the best score, e.g. production for prod. Several namespaces matching
+// equally well are listed rather than picking one.
+func fuzzyNamespace(ns string, names []string) (string, error) {
+	var best []string
+	bestScore := -1
+	for _, name := range names {
+		score, ok := FuzzyMatch(ns, name)
+		if !ok {
+			continue
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore = []string{name}, score
+		case score == bestScore:
+			best = append(best, name)
+		}
+	}
+	switch len(best) {
+	case 0:
+		return "", fmt.Errorf("namespace %q not found and matches no namespace", ns)
+	case 1:
+		return best[0], nil
+	}
+	return "", fmt.Errorf("namespace %q not found and matches several namespaces: %s", ns, strings.Join(best, ", "))
 }
 
 // DaemonsetList - return a list of DaemonSet(s)
-func DaemonsetList(opt *options.SearchOptions) *appsv1.DaemonSetList {
-	ns, o := SetOptions(opt)
-	list, err := clientset.AppsV1().DaemonSets(ns).List(*o)
+func (c *Client) DaemonsetList(opt *options.SearchOptions) (*appsv1.DaemonSetList, error) {
+	list := &appsv1.DaemonSetList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.AppsV1().DaemonSets(ns).List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get DaemonSet List")
+		return nil, err
 	}
-	return list
+	return list, nil
 }
 
 // DeploymentList - return a list of Deployment(s)
-func DeploymentList(opt *options.SearchOptions) *appsv1.DeploymentList {
-	ns, o := SetOptions(opt)
-	list, err := clientset.AppsV1().Deployments(ns).List(*o)
+func (c *Client) DeploymentList(opt *options.SearchOptions) (*appsv1.DeploymentList, error) {
+	list := &appsv1.DeploymentList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.AppsV1().Deployments(ns).List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get Deployment List")
+		return nil, err
 	}
-	return list
+	return list, nil
+}
+
+// ReplicaSetList - return a list of ReplicaSet(s)
+func (c *Client) ReplicaSetList(opt *options.SearchOptions) (*appsv1.ReplicaSetList, error) {
+	list := &appsv1.ReplicaSetList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.AppsV1().ReplicaSets(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get ReplicaSet List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// ReplicationControllerList - return a list of ReplicationController(s)
+func (c *Client) ReplicationControllerList(opt *options.SearchOptions) (*corev1.ReplicationControllerList, error) {
+	list := &corev1.ReplicationControllerList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().ReplicationControllers(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get ReplicationController List")
+		return nil, err
+	}
+	return list, nil
 }
 
 // PodList - return a list of Pod(s)
-func PodList(opt *options.SearchOptions) *corev1.PodList {
-	ns, o := SetOptions(opt)
-	list, err := clientset.CoreV1().Pods(ns).List(*o)
+func (c *Client) PodList(opt *options.SearchOptions) (*corev1.PodList, error) {
+	list := &corev1.PodList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().Pods(ns).List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get Pod List")
+		return nil, err
 	}
-	return list
+	return list, nil
 }
 
 // NodeList - return a list of Node(s)
-func NodeList(opt *options.SearchOptions) *corev1.NodeList {
-	_, o := SetOptions(opt)
-	list, err := clientset.CoreV1().Nodes().List(*o)
+func (c *Client) NodeList(opt *options.SearchOptions) (*corev1.NodeList, error) {
+	list := &corev1.NodeList{}
+	err := c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().Nodes().List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get Node List")
+		return nil, err
 	}
-	return list
+	return list, nil
+}
+
+// NodePodList - return the Pod(s) scheduled on a node, in every namespace
+func (c *Client) NodePodList(node *corev1.Node) (*corev1.PodList, error) {
+	list, err := c.Clientset.CoreV1().Pods("").List(metav1.ListOptions{FieldSelector: "spec.nodeName=" + node.Name})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Node Pod List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// PodLogs - stream the logs of one container of a pod
+func (c *Client) PodLogs(pod *corev1.Pod, opt *corev1.PodLogOptions) (io.ReadCloser, error) {
+	stream, err := c.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opt).Stream()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":       err.Error(),
+			"pod":       pod.Name,
+			"container": opt.Container,
+		}).Debug("Unable to get Pod Logs")
+		return nil, err
+	}
+	return stream, nil
+}
+
+// NamespaceList - return a list of Namespace(s)
+func (c *Client) NamespaceList(opt *options.SearchOptions) (*corev1.NamespaceList, error) {
+	list := &corev1.NamespaceList{}
+	err := c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().Namespaces().List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Namespace List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// EventList - return a list of Event(s)
+func (c *Client) EventList(opt *options.SearchOptions) (*corev1.EventList, error) {
+	list := &corev1.EventList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().Events(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Event List")
+		return nil, err
+	}
+	return list, nil
 }
 
 // ConfigMapList - return a list of ConfigMap(s)
-func ConfigMapList(opt *options.SearchOptions) *corev1.ConfigMapList {
-	ns, o := SetOptions(opt)
-	list, err := clientset.CoreV1().ConfigMaps(ns).List(*o)
+func (c *Client) ConfigMapList(opt *options.SearchOptions) (*corev1.ConfigMapList, error) {
+	list := &corev1.ConfigMapList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().ConfigMaps(ns).List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get ConfigMap List")
+		return nil, err
 	}
-	return list
+	return list, nil
 }
 
 // SecretList - return a list of Secret(s)
-func SecretList(opt *options.SearchOptions) *corev1.SecretList {
-	ns, o := SetOptions(opt)
-	list, err := clientset.CoreV1().Secrets(ns).List(*o)
+func (c *Client) SecretList(opt *options.SearchOptions) (*corev1.SecretList, error) {
+	list := &corev1.SecretList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().Secrets(ns).List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get Secret List")
+		return nil, err
 	}
-	return list
+	return list, nil
+}
+
+// ResourceQuotaList - return a list of ResourceQuota(s)
+func (c *Client) ResourceQuotaList(opt *options.SearchOptions) (*corev1.ResourceQuotaList, error) {
+	list := &corev1.ResourceQuotaList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().ResourceQuotas(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get ResourceQuota List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// LimitRangeList - return a list of LimitRange(s)
+func (c *Client) LimitRangeList(opt *options.SearchOptions) (*corev1.LimitRangeList, error) {
+	list := &corev1.LimitRangeList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().LimitRanges(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get LimitRange List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// ServiceAccountList - return a list of ServiceAccount(s)
+func (c *Client) ServiceAccountList(opt *options.SearchOptions) (*corev1.ServiceAccountList, error) {
+	list := &corev1.ServiceAccountList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().ServiceAccounts(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get ServiceAccount List")
+		return nil, err
+	}
+	return list, nil
 }
 
 // StatefulSetList - return a list of StatefulSets
-func StatefulSetList(opt *options.SearchOptions) *appsv1.StatefulSetList {
-	ns, o := SetOptions(opt)
-	list, err := clientset.AppsV1().StatefulSets(ns).List(*o)
+func (c *Client) StatefulSetList(opt *options.SearchOptions) (*appsv1.StatefulSetList, error) {
+	list := &appsv1.StatefulSetList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.AppsV1().StatefulSets(ns).List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get .StatefulSet List")
+		return nil, err
 	}
-	return list
+	return list, nil
 }
 
-func ServiceList(opt *options.SearchOptions) *corev1.ServiceList {
-	ns, o := SetOptions(opt)
-	list, err := clientset.CoreV1().Services(ns).List(*o)
+// ServiceList - return a list of Service(s)
+func (c *Client) ServiceList(opt *options.SearchOptions) (*corev1.ServiceList, error) {
+	list := &corev1.ServiceList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().Services(ns).List(o)
+	})
 	if err != nil {
 		log.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Debug("Unable to get .Services List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// PersistentVolumeClaimList - return a list of PersistentVolumeClaim(s)
+func (c *Client) PersistentVolumeClaimList(opt *options.SearchOptions) (*corev1.PersistentVolumeClaimList, error) {
+	list := &corev1.PersistentVolumeClaimList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().PersistentVolumeClaims(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get PersistentVolumeClaim List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// PersistentVolumeList - return a list of PersistentVolume(s)
+func (c *Client) PersistentVolumeList(opt *options.SearchOptions) (*corev1.PersistentVolumeList, error) {
+	list := &corev1.PersistentVolumeList{}
+	err := c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().PersistentVolumes().List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get PersistentVolume List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// RoleList - return a list of Role(s)
+func (c *Client) RoleList(opt *options.SearchOptions) (*rbacv1.RoleList, error) {
+	list := &rbacv1.RoleList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.RbacV1().Roles(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Role List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// RoleBindingList - return a list of RoleBinding(s)
+func (c *Client) RoleBindingList(opt *options.SearchOptions) (*rbacv1.RoleBindingList, error) {
+	list := &rbacv1.RoleBindingList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.RbacV1().RoleBindings(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get RoleBinding List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// ClusterRoleList - return a list of ClusterRole(s)
+func (c *Client) ClusterRoleList(opt *options.SearchOptions) (*rbacv1.ClusterRoleList, error) {
+	list := &rbacv1.ClusterRoleList{}
+	err := c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.RbacV1().ClusterRoles().List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get ClusterRole List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// ClusterRoleBindingList - return a list of ClusterRoleBinding(s)
+func (c *Client) ClusterRoleBindingList(opt *options.SearchOptions) (*rbacv1.ClusterRoleBindingList, error) {
+	list := &rbacv1.ClusterRoleBindingList{}
+	err := c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.RbacV1().ClusterRoleBindings().List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get ClusterRoleBinding List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// StorageClassList - return a list of StorageClass(es)
+func (c *Client) StorageClassList(opt *options.SearchOptions) (*storagev1.StorageClassList, error) {
+	list := &storagev1.StorageClassList{}
+	err := c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.StorageV1().StorageClasses().List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get StorageClass List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// VolumeAttachmentList - return a list of VolumeAttachment(s)
+func (c *Client) VolumeAttachmentList(opt *options.SearchOptions) (*storagev1.VolumeAttachmentList, error) {
+	list := &storagev1.VolumeAttachmentList{}
+	err := c.listClusterScoped(opt, list, func(_ string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.StorageV1().VolumeAttachments().List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get VolumeAttachment List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// EndpointsList - return a list of Endpoints
+func (c *Client) EndpointsList(opt *options.SearchOptions) (*corev1.EndpointsList, error) {
+	list := &corev1.EndpointsList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.CoreV1().Endpoints(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Endpoints List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// ServiceEndpoints - return the Endpoints of a service, nil if it has none
+func (c *Client) ServiceEndpoints(service *corev1.Service) (*corev1.Endpoints, error) {
+	endpoints, err := c.Clientset.CoreV1().Endpoints(service.Namespace).Get(service.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Service Endpoints")
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// ServicePodList - return the Pod(s) selected by a service
+func (c *Client) ServicePodList(service *corev1.Service) (*corev1.PodList, error) {
+	list, err := c.Clientset.CoreV1().Pods(service.Namespace).List(metav1.ListOptions{LabelSelector: KeysString(service.Spec.Selector)})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get service and pod List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// JobList - return a list of Job(s)
+func (c *Client) JobList(opt *options.SearchOptions) (*batchv1.JobList, error) {
+	list := &batchv1.JobList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.BatchV1().Jobs(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Job List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// CronJobList - return a list of CronJob(s)
+func (c *Client) CronJobList(opt *options.SearchOptions) (*batchv1beta1.CronJobList, error) {
+	list := &batchv1beta1.CronJobList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.BatchV1beta1().CronJobs(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get CronJob List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// IngressList - return a list of Ingress(es), falling back to extensions/v1beta1
+// on clusters that don't serve networking.k8s.io/v1beta1
+func (c *Client) IngressList(opt *options.SearchOptions) (*networkingv1beta1.IngressList, error) {
+	list := &networkingv1beta1.IngressList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		result, err := c.Clientset.NetworkingV1beta1().Ingresses(ns).List(o)
+		if !apierrors.IsNotFound(err) {
+			return result, err
+		}
+		legacy, err := c.Clientset.ExtensionsV1beta1().Ingresses(ns).List(o)
+		if err != nil {
+			return nil, err
+		}
+		return convertLegacyIngressList(legacy), nil
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get Ingress List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// convertLegacyIngressList - copy extensions/v1beta1 Ingresses into their networking equivalent
+func convertLegacyIngressList(legacy *extensionsv1beta1.IngressList) *networkingv1beta1.IngressList {
+	list := &networkingv1beta1.IngressList{ListMeta: legacy.ListMeta}
+	for _, in := range legacy.Items {
+		out := networkingv1beta1.Ingress{
+			ObjectMeta: in.ObjectMeta,
+			Status:     networkingv1beta1.IngressStatus{LoadBalancer: in.Status.LoadBalancer},
+		}
+		if in.Spec.Backend != nil {
+			out.Spec.Backend = &networkingv1beta1.IngressBackend{
+				ServiceName: in.Spec.Backend.ServiceName,
+				ServicePort: in.Spec.Backend.ServicePort,
+			}
+		}
+		for _, tls := range in.Spec.TLS {
+			out.Spec.TLS = append(out.Spec.TLS, networkingv1beta1.IngressTLS{Hosts: tls.Hosts, SecretName: tls.SecretName})
+		}
+		for _, rule := range in.Spec.Rules {
+			r := networkingv1beta1.IngressRule{Host: rule.Host}
+			if rule.HTTP != nil {
+				r.HTTP = &networkingv1beta1.HTTPIngressRuleValue{}
+				for _, path := range rule.HTTP.Paths {
+					r.HTTP.Paths = append(r.HTTP.Paths, networkingv1beta1.HTTPIngressPath{
+						Path: path.Path,
+						Backend: networkingv1beta1.IngressBackend{
+							ServiceName: path.Backend.ServiceName,
+							ServicePort: path.Backend.ServicePort,
+						},
+					})
+				}
+			}
+			out.Spec.Rules = append(out.Spec.Rules, r)
+		}
+		list.Items = append(list.Items, out)
 	}
 	return list
 }
 
-// TrimQuoteAndSpace - remove Spaces, Tabs, SingleQuotes, DoubleQuites
-func TrimQuoteAndSpace(input string) string {
-	if len(input) >= 2 {
-		if input[0] == '"' && input[len(input)-1] == '"' {
-			return input[1 : len(input)-1]
+// NetworkPolicyList - return a list of NetworkPolicy(s)
+func (c *Client) NetworkPolicyList(opt *options.SearchOptions) (*networkingv1.NetworkPolicyList, error) {
+	list := &networkingv1.NetworkPolicyList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return c.Clientset.NetworkingV1().NetworkPolicies(ns).List(o)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get NetworkPolicy List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// HorizontalPodAutoscalerList - return a list of HorizontalPodAutoscaler(s),
+// falling back to autoscaling/v1 on clusters that don't serve autoscaling/v2beta2
+func (c *Client) HorizontalPodAutoscalerList(opt *options.SearchOptions) (*autoscalingv2beta2.HorizontalPodAutoscalerList, error) {
+	list := &autoscalingv2beta2.HorizontalPodAutoscalerList{}
+	err := c.listNamespaced(opt, list, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		result, err := c.Clientset.AutoscalingV2beta2().HorizontalPodAutoscalers(ns).List(o)
+		if !apierrors.IsNotFound(err) {
+			return result, err
 		}
-		if input[0] == '\'' && input[len(input)-1] == '\'' {
-			return input[1 : len(input)-1]
+		legacy, err := c.Clientset.AutoscalingV1().HorizontalPodAutoscalers(ns).List(o)
+		if err != nil {
+			return nil, err
 		}
+		return convertLegacyHPAList(legacy), nil
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get HorizontalPodAutoscaler List")
+		return nil, err
+	}
+	return list, nil
+}
+
+// convertLegacyHPAList - copy autoscaling/v1 HPAs into their v2beta2 equivalent,
+// turning the CPU utilization target into a resource metric
+func convertLegacyHPAList(legacy *autoscalingv1.HorizontalPodAutoscalerList) *autoscalingv2beta2.HorizontalPodAutoscalerList {
+	list := &autoscalingv2beta2.HorizontalPodAutoscalerList{ListMeta: legacy.ListMeta}
+	for _, in := range legacy.Items {
+		out := autoscalingv2beta2.HorizontalPodAutoscaler{
+			ObjectMeta: in.ObjectMeta,
+			Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+					Kind:       in.Spec.ScaleTargetRef.Kind,
+					Name:       in.Spec.ScaleTargetRef.Name,
+					APIVersion: in.Spec.ScaleTargetRef.APIVersion,
+				},
+				MinReplicas: in.Spec.MinReplicas,
+				MaxReplicas: in.Spec.MaxReplicas,
+			},
+			Status: autoscalingv2beta2.HorizontalPodAutoscalerStatus{
+				ObservedGeneration: in.Status.ObservedGeneration,
+				LastScaleTime:      in.Status.LastScaleTime,
+				CurrentReplicas:    in.Status.CurrentReplicas,
+				DesiredReplicas:    in.Status.DesiredReplicas,
+			},
+		}
+		if in.Spec.TargetCPUUtilizationPercentage != nil {
+			out.Spec.Metrics = []autoscalingv2beta2.MetricSpec{{
+				Type: autoscalingv2beta2.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta2.ResourceMetricSource{
+					Name: v1.ResourceCPU,
+					Target: autoscalingv2beta2.MetricTarget{
+						Type:               autoscalingv2beta2.UtilizationMetricType,
+						AverageUtilization: in.Spec.TargetCPUUtilizationPercentage,
+					},
+				},
+			}}
+		}
+		if in.Status.CurrentCPUUtilizationPercentage != nil {
+			out.Status.CurrentMetrics = []autoscalingv2beta2.MetricStatus{{
+				Type: autoscalingv2beta2.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta2.ResourceMetricStatus{
+					Name: v1.ResourceCPU,
+					Current: autoscalingv2beta2.MetricValueStatus{
+						AverageUtilization: in.Status.CurrentCPUUtilizationPercentage,
+					},
+				},
+			}}
+		}
+		list.Items = append(list.Items, out)
 	}
-	return strings.TrimSpace(input)
+	return list
 }
 
-// GetAge - return human readable time expression
-func GetAge(d time.Duration) string {
-	return duration.HumanDuration(d)
+// IsTimeout - report whether err means the API server didn't answer in time,
+// either because the client gave up or the server did
+func IsTimeout(err error) bool {
+	if apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
-func GetDefaultNamespace() string {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	rules.DefaultClientConfig = &clientcmd.DefaultClientConfig
-	overrides := &clientcmd.ConfigOverrides{ClusterDefaults: clientcmd.ClusterDefaults}
+// TrimQuoteAndSpace - remove surrounding Spaces and Tabs, then one pair of
+// matching SingleQuotes or DoubleQuotes. Whitespace inside the quotes is
+// kept and escaped quotes, e.g. \", are unescaped. Mismatched quotes are
+// left alone.
+func TrimQuoteAndSpace(input string) string {
+	input = strings.TrimSpace(input)
+	if len(input) < 2 {
+		return input
+	}
+	quote := input[0]
+	if quote != '"' && quote != '\'' {
+		return input
+	}
+	if input[len(input)-1] != quote || isEscaped(input, len(input)-1) {
+		return input
+	}
+	inner := input[1 : len(input)-1]
+	return strings.Replace(inner, `\`+string(quote), string(quote), -1)
+}
 
-	if ns, _, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).Namespace(); err == nil {
-		return ns
+// isEscaped - report whether the byte at i is preceded by an odd number of
+// backslashes
+func isEscaped(s string, i int) bool {
+	backslashes := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		backslashes++
 	}
-	return v1.NamespaceDefault
+	return backslashes%2 == 1
 }
+
+// KeysString - render m as k=v pairs joined by commas, e.g. a label
+// selector, sorted by key so the result is the same on every run
 func KeysString(m map[string]string) string {
 	keys := make([]string, 0, len(m))
-	for k, v := range m {
-		keys = append(keys, fmt.Sprintf("%s=%s", k, v))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	return strings.Join(keys, ",")
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(m))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, m[k]))
+	}
+	return strings.Join(pairs, ",")
 }
 
-func RunCommand(name string, args ...string) []string {
-	//fmt.Printf("%v %v\n", name, args)
+// RunCommand - run name with args, returning its stdout lines and stderr
+// separately. exitCode is -1 if the command couldn't be started; err is
+// non-nil whenever it didn't exit with 0.
+func RunCommand(name string, args ...string) (stdout []string, stderr string, exitCode int, err error) {
 	cmd := exec.Command(name, args...)
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
 
-	cmdOut, err := cmd.CombinedOutput()
+	cmdOut, err := cmd.Output()
+	stdout = strings.Split(string(cmdOut), "\n")
+	stderr = errBuf.String()
 	if err != nil {
-		fmt.Printf("error running command: %v %v\n", name, args)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return stdout, stderr, exitErr.ExitCode(), err
+		}
+		return stdout, stderr, -1, err
 	}
-	output := strings.Split(string(cmdOut), "\n")
+	return stdout, stderr, 0, nil
+}
+
+// RunInteractive - run name with args attached to kk's own stdin, stdout and
+// stderr, e.g. for a shell. exitCode is -1 if the command couldn't be started.
+func RunInteractive(name string, args ...string) (exitCode int, err error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), err
+		}
+		return -1, err
+	}
+	return 0, nil
+}
+
+// kubectlDryRun - print the kubectl commands instead of running them
+var kubectlDryRun bool
+
+// EnableDryRun - make RawK8sOutput return the kubectl command it would run,
+// shell quoted, instead of running it
+func EnableDryRun() {
+	kubectlDryRun = true
+}
 
-	return output
+// RawK8sOutput - run kubectl with args against the given namespace, context,
+// selector and kubeconfig, connecting like c, failing with kubectl's own
+// error message if it fails
+func (c *Client) RawK8sOutput(namespace string, context string, labels string, kubeconfig string, timeout time.Duration, args ...string) ([]string, error) {
+	cmdArgs := K8sCommandArgs(args, namespace, context, labels, kubeconfig, timeout, c.kubectlFlags)
+	if kubectlDryRun {
+		return []string{ShellJoin("kubectl", cmdArgs...)}, nil
+	}
+	output, stderr, exitCode, err := RunCommand("kubectl", cmdArgs...)
+	if err != nil {
+		if exitCode < 0 {
+			return nil, fmt.Errorf("running kubectl: %v", err)
+		}
+		if msg := strings.TrimSpace(stderr); msg != "" {
+			return nil, fmt.Errorf("kubectl exited with %d: %s", exitCode, msg)
+		}
+		return nil, fmt.Errorf("kubectl exited with %d", exitCode)
+	}
+	return output, nil
 }
 
-func RawK8sOutput(namespace string, context string, labels string, args ...string) []string {
-	cmdArgs := K8sCommandArgs(args, namespace, context, labels)
-	output := RunCommand("kubectl", cmdArgs...)
-	return output
+// RawK8sInteractive - like RawK8sOutput, with kubectl attached to the
+// terminal instead of its output being collected. command is passed after
+// "--", e.g. to kubectl exec. It returns kubectl's exit code. There is no
+// --request-timeout, it would end an exec session or followed logs.
+func (c *Client) RawK8sInteractive(namespace string, context string, kubeconfig string, args []string, command ...string) (int, error) {
+	cmdArgs := K8sCommandArgs(args, namespace, context, "", kubeconfig, 0, c.kubectlFlags)
+	if len(command) > 0 {
+		cmdArgs = append(append(cmdArgs, "--"), command...)
+	}
+	if kubectlDryRun {
+		fmt.Println(ShellJoin("kubectl", cmdArgs...))
+		return 0, nil
+	}
+	exitCode, err := RunInteractive("kubectl", cmdArgs...)
+	if exitCode < 0 {
+		return exitCode, fmt.Errorf("running kubectl: %v", err)
+	}
+	return exitCode, nil
 }
 
-func K8sCommandArgs(args []string, namespace string, context string, labels string) []string {
+// K8sCommandArgs - append the flags kk was run with to the kubectl args,
+// skipping the ones left empty, then the connection flags of its client,
+// e.g. --as. Quotes around the selector are trimmed like keywords, the
+// selector is passed to kubectl as a single argument anyway.
+func K8sCommandArgs(args []string, namespace string, context string, labels string, kubeconfig string, timeout time.Duration, connection []string) []string {
+	labels = TrimQuoteAndSpace(labels)
 	if namespace != "" {
 		args = append(args, fmt.Sprintf("--namespace=%v", namespace))
 	}
@@ -213,5 +1262,11 @@ func K8sCommandArgs(args []string, namespace string, context string, labels stri
 	if labels != "" {
 		args = append(args, fmt.Sprintf("--selector=%v", labels))
 	}
-	return args
+	if kubeconfig != "" {
+		args = append(args, fmt.Sprintf("--kubeconfig=%v", kubeconfig))
+	}
+	if timeout > 0 {
+		args = append(args, fmt.Sprintf("--request-timeout=%v", timeout))
+	}
+	return append(args, connection...)
 }
@@ -0,0 +1,400 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mateo1647/kk/internal/options"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func testNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func testPod(namespace, name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		UID:       types.UID(namespace + "/" + name),
+		Labels:    labels,
+	}}
+}
+
+func testDeployment(namespace, name string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		UID:       types.UID(namespace + "/" + name),
+	}}
+}
+
+// seedCluster - search through a fake clientset holding pods and
+// deployments in the namespaces default, team-a and team-b
+func seedCluster(t *testing.T, namespace string) *fake.Clientset {
+	t.Helper()
+	clientset := fake.NewSimpleClientset(
+		testNamespace("default"),
+		testNamespace("team-a"),
+		testNamespace("team-b"),
+		testPod("default", "web-1", map[string]string{"app": "web"}),
+		testPod("team-a", "web-1", map[string]string{"app": "web"}),
+		testPod("team-a", "api-1", map[string]string{"app": "api"}),
+		testPod("team-b", "api-1", map[string]string{"app": "api"}),
+		testDeployment("default", "web"),
+		testDeployment("team-a", "web"),
+		testDeployment("team-b", "api"),
+	)
+	SetClient(Client{Clientset: clientset, Namespace: namespace})
+	return clientset
+}
+
+// objectKeys - the sorted namespace/name of every object in list
+func objectKeys(t *testing.T, list runtime.Object) []string {
+	t.Helper()
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, accessor.GetNamespace()+"/"+accessor.GetName())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestSetOptionsNamespace(t *testing.T) {
+	seedCluster(t, "team-a")
+	defer os.Setenv(podNamespaceEnv, os.Getenv(podNamespaceEnv))
+	os.Unsetenv(podNamespaceEnv)
+
+	tests := []struct {
+		name string
+		opt  options.SearchOptions
+		env  string
+		want []string
+	}{
+		{name: "context namespace", want: []string{"team-a"}},
+		{name: "-n wins", opt: options.SearchOptions{Namespaces: []string{"team-b"}}, want: []string{"team-b"}},
+		{name: "-n trimmed and de-duplicated", opt: options.SearchOptions{Namespaces: []string{" team-b", "team-b", ""}}, want: []string{"team-b"}},
+		{name: "-A searches every namespace", opt: options.SearchOptions{AllNamespaces: true, Namespaces: []string{"team-b"}}, want: []string{""}},
+		{name: "pod namespace before context", env: "kk", want: []string{"kk"}},
+		{name: "-n before pod namespace", opt: options.SearchOptions{Namespaces: []string{"team-b"}}, env: "kk", want: []string{"team-b"}},
+		{name: "config file before context", opt: options.SearchOptions{DefaultNamespaces: []string{"team-b"}}, want: []string{"team-b"}},
+		{name: "pod namespace before config file", opt: options.SearchOptions{DefaultNamespaces: []string{"team-b"}}, env: "kk", want: []string{"kk"}},
+		{name: "-n before config file", opt: options.SearchOptions{Namespaces: []string{"default"}, DefaultNamespaces: []string{"team-b"}}, want: []string{"default"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(podNamespaceEnv, tt.env)
+			namespaces, _ := ClientFor(&tt.opt).SetOptions(&tt.opt)
+			if !reflect.DeepEqual(namespaces, tt.want) {
+				t.Errorf("SetOptions() namespaces = %q, want %q", namespaces, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetOptionsDefaultNamespace(t *testing.T) {
+	seedCluster(t, "")
+	defer os.Setenv(podNamespaceEnv, os.Getenv(podNamespaceEnv))
+	os.Unsetenv(podNamespaceEnv)
+
+	opt := &options.SearchOptions{}
+	namespaces, _ := ClientFor(opt).SetOptions(opt)
+	if want := []string{"default"}; !reflect.DeepEqual(namespaces, want) {
+		t.Errorf("SetOptions() namespaces = %q, want %q", namespaces, want)
+	}
+}
+
+func TestPodList(t *testing.T) {
+	seedCluster(t, "team-a")
+	defer os.Setenv(podNamespaceEnv, os.Getenv(podNamespaceEnv))
+	os.Unsetenv(podNamespaceEnv)
+
+	tests := []struct {
+		name string
+		opt  options.SearchOptions
+		want []string
+	}{
+		{name: "context namespace", want: []string{"team-a/api-1", "team-a/web-1"}},
+		{name: "-n", opt: options.SearchOptions{Namespaces: []string{"team-b", "default"}}, want: []string{"default/web-1", "team-b/api-1"}},
+		{name: "-A", opt: options.SearchOptions{AllNamespaces: true}, want: []string{"default/web-1", "team-a/api-1", "team-a/web-1", "team-b/api-1"}},
+		{name: "-A fanned out", opt: options.SearchOptions{AllNamespaces: true, Concurrency: 4}, want: []string{"default/web-1", "team-a/api-1", "team-a/web-1", "team-b/api-1"}},
+		{name: "-l", opt: options.SearchOptions{Selector: "app=api"}, want: []string{"team-a/api-1"}},
+		{name: "-A -l", opt: options.SearchOptions{AllNamespaces: true, Selector: "app=web"}, want: []string{"default/web-1", "team-a/web-1"}},
+		{name: "-A -l matching nothing", opt: options.SearchOptions{AllNamespaces: true, Selector: "app=db"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := ClientFor(&tt.opt).PodList(&tt.opt)
+			if err != nil {
+				t.Fatalf("PodList() error = %v", err)
+			}
+			if got := objectKeys(t, list); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PodList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeploymentList(t *testing.T) {
+	seedCluster(t, "default")
+
+	opt := &options.SearchOptions{AllNamespaces: true}
+	list, err := ClientFor(opt).DeploymentList(opt)
+	if err != nil {
+		t.Fatalf("DeploymentList() error = %v", err)
+	}
+	want := []string{"default/web", "team-a/web", "team-b/api"}
+	if got := objectKeys(t, list); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeploymentList() = %q, want %q", got, want)
+	}
+}
+
+// forbidPods - make listing pods in namespace fail like RBAC denying it
+func forbidPods(clientset *fake.Clientset, namespace string) {
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() != namespace {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+	})
+}
+
+func TestListNamespacedSkipsForbidden(t *testing.T) {
+	clientset := seedCluster(t, "default")
+	forbidPods(clientset, "team-a")
+
+	for _, concurrency := range []int{1, 4} {
+		opt := &options.SearchOptions{Namespaces: []string{"team-a", "team-b"}, Concurrency: concurrency}
+		list, err := ClientFor(opt).PodList(opt)
+		if err != nil {
+			t.Fatalf("PodList() with concurrency %d error = %v, want team-a skipped", concurrency, err)
+		}
+		if got, want := objectKeys(t, list), []string{"team-b/api-1"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("PodList() with concurrency %d = %q, want %q", concurrency, got, want)
+		}
+	}
+
+	opt := &options.SearchOptions{AllNamespaces: true, Concurrency: 4}
+	list, err := ClientFor(opt).PodList(opt)
+	if err != nil {
+		t.Fatalf("PodList() -A error = %v, want team-a skipped", err)
+	}
+	if got, want := objectKeys(t, list), []string{"default/web-1", "team-b/api-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PodList() -A = %q, want %q", got, want)
+	}
+}
+
+func TestListNamespacedForbiddenSingleNamespace(t *testing.T) {
+	clientset := seedCluster(t, "default")
+	forbidPods(clientset, "team-a")
+
+	opt := &options.SearchOptions{Namespaces: []string{"team-a"}}
+	if _, err := ClientFor(opt).PodList(opt); !apierrors.IsForbidden(err) {
+		t.Errorf("PodList() error = %v, want Forbidden", err)
+	}
+}
+
+// benchmarkRoundTrip - the latency added to every list of pods, standing in
+// for the round trip to an API server
+const benchmarkRoundTrip = time.Millisecond
+
+// slowClientset - a fake clientset whose pod lists take benchmarkRoundTrip
+// longer. Reactors run under the lock of the fake, so the latency is added
+// around it for concurrent lists to wait at the same time.
+type slowClientset struct {
+	*fake.Clientset
+}
+
+func (c slowClientset) CoreV1() typedcorev1.CoreV1Interface {
+	return slowCoreV1{c.Clientset.CoreV1()}
+}
+
+type slowCoreV1 struct {
+	typedcorev1.CoreV1Interface
+}
+
+func (c slowCoreV1) Pods(namespace string) typedcorev1.PodInterface {
+	return slowPods{c.CoreV1Interface.Pods(namespace)}
+}
+
+type slowPods struct {
+	typedcorev1.PodInterface
+}
+
+func (p slowPods) List(o metav1.ListOptions) (*corev1.PodList, error) {
+	time.Sleep(benchmarkRoundTrip)
+	return p.PodInterface.List(o)
+}
+
+// BenchmarkListNamespaced - list 5000 pods spread over the 50 namespaces
+// given with -n, one namespace at a time and on a pool of workers
+func BenchmarkListNamespaced(b *testing.B) {
+	var namespaces []string
+	var objects []runtime.Object
+	for n := 0; n < 50; n++ {
+		namespace := fmt.Sprintf("ns-%02d", n)
+		namespaces = append(namespaces, namespace)
+		objects = append(objects, testNamespace(namespace))
+		for p := 0; p < 100; p++ {
+			objects = append(objects, testPod(namespace, fmt.Sprintf("pod-%03d", p), nil))
+		}
+	}
+	SetClient(Client{Clientset: slowClientset{fake.NewSimpleClientset(objects...)}})
+
+	for _, concurrency := range []int{1, 8} {
+		name := "serial"
+		if concurrency > 1 {
+			name = fmt.Sprintf("concurrency-%d", concurrency)
+		}
+		b.Run(name, func(b *testing.B) {
+			opt := &options.SearchOptions{Namespaces: namespaces, Concurrency: concurrency}
+			for i := 0; i < b.N; i++ {
+				list, err := ClientFor(opt).PodList(opt)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(list.Items) != 5000 {
+					b.Fatalf("PodList() = %d pods, want 5000", len(list.Items))
+				}
+			}
+		})
+	}
+}
+
+func TestK8sCommandArgs(t *testing.T) {
+	connection := []string{"--as=jane", "--as-group=dev"}
+	tests := []struct {
+		name       string
+		namespace  string
+		context    string
+		labels     string
+		kubeconfig string
+		timeout    time.Duration
+		want       []string
+	}{
+		{
+			name: "only the connection flags",
+			want: []string{"get", "pod/web-1", "--as=jane", "--as-group=dev"},
+		},
+		{
+			name:       "every flag in order",
+			namespace:  "team-a",
+			context:    "prod",
+			labels:     "app=web",
+			kubeconfig: "/tmp/config",
+			timeout:    1500 * time.Millisecond,
+			want: []string{"get", "pod/web-1",
+				"--namespace=team-a",
+				"--context=prod",
+				"--selector=app=web",
+				"--kubeconfig=/tmp/config",
+				"--request-timeout=1.5s",
+				"--as=jane", "--as-group=dev"},
+		},
+		{
+			name:   "quotes around the selector trimmed",
+			labels: `'app in (web, api)'`,
+			want:   []string{"get", "pod/web-1", "--selector=app in (web, api)", "--as=jane", "--as-group=dev"},
+		},
+		{
+			name:      "empty flags skipped",
+			namespace: "team-a",
+			want:      []string{"get", "pod/web-1", "--namespace=team-a", "--as=jane", "--as-group=dev"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := K8sCommandArgs([]string{"get", "pod/web-1"}, tt.namespace, tt.context, tt.labels, tt.kubeconfig, tt.timeout, connection)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("K8sCommandArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimQuoteAndSpace(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: `" x "`, want: ` x `},
+		{input: `' x '`, want: ` x `},
+		{input: "\t\"x\" ", want: `x`},
+		{input: `"x'`, want: `"x'`},
+		{input: `'x"`, want: `'x"`},
+		{input: ``, want: ``},
+		{input: `  `, want: ``},
+		{input: `"`, want: `"`},
+		{input: `""`, want: ``},
+		{input: `x`, want: `x`},
+		{input: `"say \"hi\""`, want: `say "hi"`},
+		{input: `'it\'s'`, want: `it's`},
+		{input: `"x\"`, want: `"x\"`},
+		{input: `"x\\"`, want: `x\\`},
+	}
+	for _, tt := range tests {
+		if got := TrimQuoteAndSpace(tt.input); got != tt.want {
+			t.Errorf("TrimQuoteAndSpace(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIsEscaped(t *testing.T) {
+	tests := []struct {
+		s    string
+		i    int
+		want bool
+	}{
+		{s: `"x"`, i: 2, want: false},
+		{s: `"x\"`, i: 3, want: true},
+		{s: `"x\\"`, i: 4, want: false},
+		{s: `"x\\\"`, i: 5, want: true},
+		{s: `"`, i: 0, want: false},
+	}
+	for _, tt := range tests {
+		if got := isEscaped(tt.s, tt.i); got != tt.want {
+			t.Errorf("isEscaped(%q, %d) = %v, want %v", tt.s, tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestKeysString(t *testing.T) {
+	labels := map[string]string{
+		"tier":                   "backend",
+		"app":                    "web",
+		"app.kubernetes.io/name": "web",
+		"version":                "v2",
+	}
+	want := "app=web,app.kubernetes.io/name=web,tier=backend,version=v2"
+	// map iteration order changes from run to run, the output must not
+	for i := 0; i < 20; i++ {
+		if got := KeysString(labels); got != want {
+			t.Fatalf("KeysString() = %q, want %q", got, want)
+		}
+	}
+	if got := KeysString(nil); got != "" {
+		t.Errorf("KeysString(nil) = %q, want empty", got)
+	}
+}
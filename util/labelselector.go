@@ -0,0 +1,71 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/mateo1647/kk/internal/options"
+)
+
+// ValidateSelector - parse a --selector the way the API server will, so a
+// malformed one fails with a clear message before any API call is made.
+// Besides key=value, key!=value and set-based requirements such as key,
+// !key, key in (a,b) and key notin (a,b) are accepted and sent as given.
+func ValidateSelector(selector string) error {
+	if selector == "" {
+		return nil
+	}
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid --selector %q: %v", selector, err)
+	}
+	return nil
+}
+
+// DeploymentSelector - the namespace of the deployment called name, searched
+// for in the namespaces of opt, and the selector of its pods, with
+// matchExpressions as set-based requirements. A selector that would match
+// every pod is refused.
+func (c *Client) DeploymentSelector(opt *options.SearchOptions, name string) (string, string, error) {
+	// the selectors given are for the pods, not the deployment
+	lookup := *opt
+	lookup.Selector, lookup.FieldSelector = "", ""
+	list, err := c.DeploymentList(&lookup)
+	if err != nil {
+		return "", "", err
+	}
+
+	var found []appsv1.Deployment
+	for _, deployment := range list.Items {
+		if deployment.Name == name {
+			found = append(found, deployment)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", "", fmt.Errorf("deployment %q not found", name)
+	case 1:
+	default:
+		var namespaces []string
+		for _, deployment := range found {
+			namespaces = append(namespaces, deployment.Namespace)
+		}
+		return "", "", fmt.Errorf("deployment %q exists in %s, pick one with -n", name, strings.Join(namespaces, ", "))
+	}
+
+	deployment := found[0]
+	if deployment.Spec.Selector == nil {
+		return "", "", fmt.Errorf("deployment %q has no selector", name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", "", fmt.Errorf("deployment %q has an invalid selector: %v", name, err)
+	}
+	if selector.Empty() {
+		return "", "", fmt.Errorf("deployment %q has an empty selector, it would match every pod", name)
+	}
+	return deployment.Namespace, selector.String(), nil
+}
@@ -0,0 +1,39 @@
+package util
+
+import "testing"
+
+func TestValidateSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		valid    bool
+	}{
+		{selector: "", valid: true},
+		{selector: "app=web", valid: true},
+		{selector: "app==web", valid: true},
+		{selector: "app!=web", valid: true},
+		{selector: "app=web,tier=backend", valid: true},
+		{selector: "app", valid: true},
+		{selector: "!app", valid: true},
+		{selector: "app in (web,api)", valid: true},
+		{selector: "app notin (web)", valid: true},
+		{selector: "app in (web),!canary,tier!=frontend", valid: true},
+		{selector: "app.kubernetes.io/name=web", valid: true},
+		{selector: "app=", valid: true},
+		{selector: "app in web", valid: false},
+		{selector: "app in (web", valid: false},
+		{selector: "app notin", valid: false},
+		{selector: "=web", valid: false},
+		{selector: "!app=web", valid: false},
+		{selector: "app=web,", valid: false},
+		{selector: "app=we b", valid: false},
+	}
+	for _, tt := range tests {
+		err := ValidateSelector(tt.selector)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateSelector(%q) error = %v, want none", tt.selector, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("ValidateSelector(%q) error = nil, want invalid --selector", tt.selector)
+		}
+	}
+}
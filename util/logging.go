@@ -0,0 +1,36 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LogFormats - the values accepted by --log-format
+var LogFormats = []string{"text", "json"}
+
+// SetLogFormat - choose how log lines are written to stderr: logrus' text
+// formatter or one JSON object per line, for kk running in automation
+func SetLogFormat(format string) error {
+	switch format {
+	case "text":
+		log.SetFormatter(&log.TextFormatter{})
+	case "json":
+		log.SetFormatter(&log.JSONFormatter{})
+	default:
+		return fmt.Errorf("invalid --log-format %q, expected one of: %s", format, strings.Join(LogFormats, "|"))
+	}
+	return nil
+}
+
+// SetLogLevel - only log messages at level or above, one of the logrus
+// levels: trace, debug, info, warning, error, fatal or panic
+func SetLogLevel(level string) error {
+	l, err := log.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid --log-level %q, expected one of: trace|debug|info|warning|error|fatal|panic", level)
+	}
+	log.SetLevel(l)
+	return nil
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/mateo1647/kk/internal/options"
+)
+
+// podMetricsResource - pod usage as served by metrics-server
+var podMetricsResource = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+// PodMetrics - the CPU and memory a pod's containers use, as read from
+// metrics.k8s.io/v1beta1. It mirrors the PodMetrics type of k8s.io/metrics,
+// which is all kk needs of that module.
+type PodMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Containers        []ContainerMetrics `json:"containers"`
+}
+
+// ContainerMetrics - the usage of one container of a PodMetrics
+type ContainerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// ErrNoMetrics - the metrics API isn't served, metrics-server isn't installed
+var ErrNoMetrics = fmt.Errorf("the metrics API (metrics.k8s.io) is not available, is metrics-server installed?")
+
+// PodMetricsList - return the usage of the pods in the searched namespaces,
+// or ErrNoMetrics when the cluster has no metrics API
+func (c *Client) PodMetricsList(opt *options.SearchOptions) ([]PodMetrics, error) {
+	// the metrics API only supports label selectors
+	metricsOpt := *opt
+	metricsOpt.FieldSelector = ""
+
+	list, err := c.DynamicList(&metricsOpt, podMetricsResource, true)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		err = ErrNoMetrics
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err": err.Error(),
+		}).Debug("Unable to get PodMetrics List")
+		return nil, err
+	}
+
+	metrics := make([]PodMetrics, len(list.Items))
+	for i := range list.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &metrics[i]); err != nil {
+			return nil, fmt.Errorf("decoding pod metrics: %v", err)
+		}
+	}
+	return metrics, nil
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var (
+	outputFile *os.File
+	// the size of the file before kk wrote to it, and stdout it replaced
+	outputFileSize   int64
+	outputFileStdout *os.File
+)
+
+// StartOutputFile - write everything printed to stdout to path from now on,
+// creating its parent directories. The file is truncated unless appending,
+// as --watch does so every redraw is kept.
+func StartOutputFile(path string, appending bool) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("unable to create the directory of --output-file: %v", err)
+		}
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appending {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open --output-file: %v", err)
+	}
+	if info, err := f.Stat(); err == nil {
+		outputFileSize = info.Size()
+	}
+	// the file should hold the output, not the terminal's color codes
+	DisableColor()
+	outputFileStdout, outputFile = os.Stdout, f
+	os.Stdout = f
+	return nil
+}
+
+// WritingToFile - report whether output goes to --output-file
+func WritingToFile() bool {
+	return outputFile != nil
+}
+
+// StopOutputFile - close the --output-file and report how much was written
+// to it on stderr
+func StopOutputFile() {
+	if outputFile == nil {
+		return
+	}
+	var written int64
+	if info, err := outputFile.Stat(); err == nil {
+		written = info.Size() - outputFileSize
+	}
+	outputFile.Close()
+	os.Stdout = outputFileStdout
+	fmt.Fprintf(os.Stderr, "wrote %d bytes to %s\n", written, outputFile.Name())
+	outputFile = nil
+}
+
+// DiscardOutput - drop everything printed to stdout from now on, for --quiet
+// where only the exit code matters. Logs and errors still go to stderr.
+func DiscardOutput() error {
+	f, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open %s for --quiet: %v", os.DevNull, err)
+	}
+	DisableColor()
+	os.Stdout = f
+	return nil
+}
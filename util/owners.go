@@ -0,0 +1,43 @@
+package util
+
+import (
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GetOwner - fetch the object an owner reference of something in namespace
+// points to, whatever its kind, through the dynamic client
+func (c *Client) GetOwner(namespace string, ref metav1.OwnerReference) (*unstructured.Unstructured, error) {
+	dc, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := c.getRESTMapper().RESTMapping(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := dc.Resource(mapping.Resource)
+	var owner *unstructured.Unstructured
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		owner, err = resource.Namespace(namespace).Get(ref.Name, metav1.GetOptions{})
+	} else {
+		owner, err = resource.Get(ref.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"err":   err.Error(),
+			"kind":  ref.Kind,
+			"owner": ref.Name,
+		}).Debug("Unable to get Owner")
+		return nil, err
+	}
+	return owner, nil
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultPager - what output is paged through without $PAGER: quit when it
+// fits on one screen, pass the highlight colors through and leave the output
+// on screen afterwards, like git
+const defaultPager = "less -FRX"
+
+var (
+	pagerCmd *exec.Cmd
+	stdout   *os.File
+)
+
+// StartPager - send everything printed to stdout through $PAGER from now on.
+// Output is printed directly when the pager can't be found or is cat.
+func StartPager() {
+	pager := strings.TrimSpace(os.Getenv("PAGER"))
+	if pager == "" {
+		pager = defaultPager
+	}
+	fields := strings.Fields(pager)
+	if fields[0] == "cat" {
+		return
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		log.WithFields(log.Fields{
+			"err":   err.Error(),
+			"pager": pager,
+		}).Debug("Unable to find pager, printing directly")
+		return
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return
+	}
+	// run through the shell, $PAGER can hold arguments in quotes
+	cmd := exec.Command("sh", "-c", pager)
+	cmd.Stdin = r
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		log.WithFields(log.Fields{
+			"err":   err.Error(),
+			"pager": pager,
+		}).Debug("Unable to start pager, printing directly")
+		r.Close()
+		w.Close()
+		return
+	}
+	r.Close()
+	pagerCmd, stdout = cmd, os.Stdout
+	os.Stdout = w
+}
+
+// StopPager - wait for the pager to show everything printed and be quit,
+// before kk exits
+func StopPager() {
+	if pagerCmd == nil {
+		return
+	}
+	os.Stdout.Close()
+	os.Stdout = stdout
+	pagerCmd.Wait()
+	pagerCmd = nil
+}
@@ -0,0 +1,269 @@
+package util
+
+import (
+	"bytes"
+	"encoding/csv"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// printHeaders - print the header row of tables, see DisableHeaders
+var printHeaders = true
+
+// DisableHeaders - leave the header row out of every table, for --no-headers
+func DisableHeaders() {
+	printHeaders = false
+}
+
+// PrintTable - print a header followed by tab separated rows, aligned in columns
+func PrintTable(header string, lines []string) {
+	printTable(os.Stdout, header, lines)
+}
+
+// PrintGroupedTable - print a table with its rows under the title of their
+// group, groups[i] being the title of lines[i]. Groups come in the order of
+// their first row, rows without a title last under untitled. Rows are
+// indented below their title and aligned across all groups.
+func PrintGroupedTable(header string, lines []string, groups []string, untitled string) {
+	var titles []string
+	rows := map[string][]string{}
+	for i, line := range lines {
+		title := groups[i]
+		if _, ok := rows[title]; !ok && title != "" {
+			titles = append(titles, title)
+		}
+		rows[title] = append(rows[title], "  "+line)
+	}
+	if len(rows[""]) > 0 {
+		titles = append(titles, "")
+	}
+
+	var ordered []string
+	for _, title := range titles {
+		ordered = append(ordered, rows[title]...)
+	}
+	var table bytes.Buffer
+	printTable(&table, "  "+header, ordered)
+	aligned := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+	if printHeaders {
+		fmt.Println(aligned[0])
+		aligned = aligned[1:]
+	}
+	for _, title := range titles {
+		n := len(rows[title])
+		if title == "" {
+			title = untitled
+		}
+		fmt.Println(title)
+		for _, line := range aligned[:n] {
+			fmt.Println(line)
+		}
+		aligned = aligned[n:]
+	}
+}
+
+func printTable(out io.Writer, header string, lines []string) {
+	for _, line := range lines {
+		if strings.Contains(line, "\x1b[") {
+			printColoredTable(out, header, lines)
+			return
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if printHeaders {
+		fmt.Fprintln(w, header)
+	}
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+}
+
+// ansiEscape - the color sequences Highlight adds to a cell
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// printColoredTable - align the table like PrintTable does, but measure cells
+// without their color sequences, which tabwriter would count as text
+func printColoredTable(w io.Writer, header string, lines []string) {
+	var rows [][]string
+	if printHeaders {
+		rows = append(rows, strings.Split(header, "\t"))
+	}
+	for _, line := range lines {
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row[:len(row)-1] {
+			n := utf8.RuneCountInString(ansiEscape.ReplaceAllString(cell, ""))
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	for _, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				n := utf8.RuneCountInString(ansiEscape.ReplaceAllString(cell, ""))
+				b.WriteString(strings.Repeat(" ", widths[i]-n+3))
+			}
+		}
+		fmt.Fprintln(w, b.String())
+	}
+}
+
+// PrintDelimited - print a header and tab separated rows as comma separated
+// values, or with another separator such as a tab, quoted as needed. Color
+// is stripped from the cells.
+func PrintDelimited(w io.Writer, comma rune, header string, lines []string) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if printHeaders {
+		if err := cw.Write(strings.Split(header, "\t")); err != nil {
+			return err
+		}
+	}
+	for _, line := range lines {
+		if err := cw.Write(strings.Split(ansiEscape.ReplaceAllString(line, ""), "\t")); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// PrintObjects - serialize objects as kubectl compatible json or yaml. A single
+// object is printed on its own, anything else is wrapped in a List.
+func PrintObjects(w io.Writer, format string, objects []runtime.Object) error {
+	for _, obj := range objects {
+		setKind(obj)
+	}
+
+	var out runtime.Object
+	if len(objects) == 1 {
+		out = objects[0]
+	} else {
+		list := &metav1.List{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+			Items:    []runtime.RawExtension{},
+		}
+		for _, obj := range objects {
+			list.Items = append(list.Items, runtime.RawExtension{Object: obj})
+		}
+		out = list
+	}
+
+	if format == "yaml" {
+		return json.NewYAMLSerializer(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme).Encode(out, w)
+	}
+
+	// encode compactly and indent with the standard library, the pretty
+	// printer of the vendored json-iterator panics on recent Go releases
+	buf := bytes.NewBuffer(nil)
+	if err := json.NewSerializer(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, false).Encode(out, buf); err != nil {
+		return err
+	}
+	indented := bytes.NewBuffer(nil)
+	if err := stdjson.Indent(indented, buf.Bytes(), "", "    "); err != nil {
+		return err
+	}
+	_, err := indented.WriteTo(w)
+	return err
+}
+
+// PrintNames - print kind/name for every object, e.g. pod/api-1 or
+// deployment.apps/web, like kubectl -o name. withNamespace prefixes
+// namespaced objects with -n <namespace>, so each line can be passed to
+// kubectl on its own, e.g. with xargs -L1 kubectl delete.
+func PrintNames(w io.Writer, objects []runtime.Object, withNamespace bool) error {
+	for _, obj := range objects {
+		name, err := ObjectName(obj)
+		if err != nil {
+			return err
+		}
+		if namespace := objectNamespace(obj); withNamespace && namespace != "" {
+			name = "-n " + namespace + " " + name
+		}
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintKubectlCommands - print the kubectl get command of every object, with
+// the namespace, context, selector, kubeconfig and timeout kk ran against,
+// shell quoted so it can be copied and pasted, connecting like c. kubectl
+// takes no name together with a selector, so with one the object is picked
+// with --field-selector=metadata.name instead.
+func (c *Client) PrintKubectlCommands(w io.Writer, objects []runtime.Object, context string, labels string, kubeconfig string, timeout time.Duration) error {
+	for _, obj := range objects {
+		name, err := ObjectName(obj)
+		if err != nil {
+			return err
+		}
+		get := []string{"get", name}
+		if TrimQuoteAndSpace(labels) != "" {
+			i := strings.Index(name, "/")
+			get = []string{"get", name[:i], "--field-selector=metadata.name=" + name[i+1:]}
+		}
+		args := K8sCommandArgs(get, objectNamespace(obj), context, labels, kubeconfig, timeout, c.kubectlFlags)
+		if _, err := fmt.Fprintln(w, ShellJoin("kubectl", args...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObjectName - the kind[.group]/name of obj, e.g. deployment.apps/web
+func ObjectName(obj runtime.Object) (string, error) {
+	setKind(obj)
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", err
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	kind := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		kind += "." + gvk.Group
+	}
+	return kind + "/" + accessor.GetName(), nil
+}
+
+func objectNamespace(obj runtime.Object) string {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		return accessor.GetNamespace()
+	}
+	return ""
+}
+
+// setKind - fill in apiVersion/kind, which the API server leaves empty on
+// the items of a List response
+func setKind(obj runtime.Object) {
+	gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil || len(gvks) == 0 {
+		return
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+}
@@ -0,0 +1,76 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPrintNames(t *testing.T) {
+	objects := []runtime.Object{
+		testPod("team-a", "api-1", nil),
+		testPod("team-b", "api-1", nil),
+		testDeployment("team-a", "web"),
+		testNamespace("team-a"),
+	}
+	tests := []struct {
+		name          string
+		withNamespace bool
+		want          string
+	}{
+		{
+			name: "one namespace",
+			want: "pod/api-1\npod/api-1\ndeployment.apps/web\nnamespace/team-a\n",
+		},
+		{
+			name:          "--all-namespaces",
+			withNamespace: true,
+			want:          "-n team-a pod/api-1\n-n team-b pod/api-1\n-n team-a deployment.apps/web\nnamespace/team-a\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := PrintNames(&out, objects, tt.withNamespace); err != nil {
+				t.Fatalf("PrintNames() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("PrintNames() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintKubectlCommands(t *testing.T) {
+	c := &Client{kubectlFlags: []string{"--as=jane"}}
+	objects := []runtime.Object{testPod("team-a", "api-1", nil), testDeployment("team-b", "web")}
+	tests := []struct {
+		name   string
+		labels string
+		want   string
+	}{
+		{
+			name: "by name",
+			want: "kubectl get pod/api-1 --namespace=team-a --context=prod --as=jane\n" +
+				"kubectl get deployment.apps/web --namespace=team-b --context=prod --as=jane\n",
+		},
+		{
+			name:   "-l",
+			labels: `"app in (api, web)"`,
+			want: "kubectl get pod --field-selector=metadata.name=api-1 --namespace=team-a --context=prod '--selector=app in (api, web)' --as=jane\n" +
+				"kubectl get deployment.apps --field-selector=metadata.name=web --namespace=team-b --context=prod '--selector=app in (api, web)' --as=jane\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := c.PrintKubectlCommands(&out, objects, "prod", tt.labels, "", 0); err != nil {
+				t.Fatalf("PrintKubectlCommands() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("PrintKubectlCommands() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
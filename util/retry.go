@@ -0,0 +1,61 @@
+package util
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// retryBackoff - the wait before the first retry, doubled for every retry after it
+var retryBackoff = wait.Backoff{
+	Duration: 250 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// withRetries - wrap list so a call failing with a transient error is
+// retried up to retries times, backing off exponentially in between
+func withRetries(retries int, list listFunc) listFunc {
+	if retries <= 0 {
+		return list
+	}
+	return func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		backoff := retryBackoff
+		backoff.Steps = retries + 1
+
+		var result runtime.Object
+		attempt := 0
+		err := retry.OnError(backoff, IsTransient, func() error {
+			if attempt > 0 {
+				log.WithFields(log.Fields{
+					"namespace": ns,
+					"attempt":   attempt,
+				}).Debug("Retrying list")
+			}
+			attempt++
+
+			var err error
+			result, err = list(ns, o)
+			return err
+		})
+		return result, err
+	}
+}
+
+// IsTransient - report whether err is likely to go away on retry: the API
+// server throttling, timing out or being unavailable, or the connection to
+// it failing. Errors about the request itself, like 403 or 404, are not.
+func IsTransient(err error) bool {
+	return apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		IsTimeout(err) ||
+		utilnet.IsConnectionRefused(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err)
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+// shellSafe - arguments made only of these characters need no quoting
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// ShellJoin - render a command line that a POSIX shell splits back into
+// exactly name and args, so it is safe to copy and paste
+func ShellJoin(name string, args ...string) string {
+	quoted := []string{ShellQuote(name)}
+	for _, arg := range args {
+		quoted = append(quoted, ShellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ShellQuote - quote s for a POSIX shell, leaving it alone if that isn't needed
+func ShellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	// single quotes keep everything literal; end them around each ' instead
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,106 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/mateo1647/kk/internal/options"
+	"github.com/mateo1647/kk/pkg/client"
+)
+
+// tableAccept - ask for lists as the table kubectl prints, v1 from
+// Kubernetes 1.15 and v1beta1 before that
+const tableAccept = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json;as=Table;v=v1beta1;g=meta.k8s.io"
+
+func (c *Client) getTableClient() (rest.Interface, error) {
+	c.lazy.tableOnce.Do(func() {
+		if c.Config == nil {
+			c.lazy.tableErr = fmt.Errorf("no client to ask for server-side tables with")
+			return
+		}
+		c.lazy.table, c.lazy.tableErr = client.InitTableClient(c.Config, tableAccept)
+	})
+	return c.lazy.table, c.lazy.tableErr
+}
+
+// ServerTableList - return gvr as the table the API server prints for it, the
+// columns kubectl shows for any resource. Every row carries its whole object,
+// for matching and machine readable output.
+func (c *Client) ServerTableList(opt *options.SearchOptions, gvr schema.GroupVersionResource, namespaced bool) (*metav1.Table, error) {
+	rc, err := c.getTableClient()
+	if err != nil {
+		return nil, err
+	}
+	namespaces, o := c.SetOptions(opt)
+	if !namespaced {
+		namespaces = []string{""}
+	}
+
+	list := withRetries(opt.Retries, func(ns string, o metav1.ListOptions) (runtime.Object, error) {
+		return tablePage(rc, gvr, ns, o)
+	})
+	table := &metav1.Table{}
+	for _, ns := range namespaces {
+		page := *o
+		for {
+			obj, err := list(ns, page)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"err":      err.Error(),
+					"resource": gvr.String(),
+				}).Debug("Unable to get server-side Table")
+				return nil, err
+			}
+			result := obj.(*metav1.Table)
+			table.ColumnDefinitions = result.ColumnDefinitions
+			table.Rows = append(table.Rows, result.Rows...)
+			if result.Continue == "" {
+				break
+			}
+			page.Continue = result.Continue
+		}
+	}
+	return table, nil
+}
+
+// tablePage - one page of the table of gvr in ns
+func tablePage(rc rest.Interface, gvr schema.GroupVersionResource, ns string, o metav1.ListOptions) (*metav1.Table, error) {
+	segments := []string{"/apis", gvr.Group, gvr.Version}
+	if gvr.Group == "" {
+		segments = []string{"/api", gvr.Version}
+	}
+	if ns != "" {
+		segments = append(segments, "namespaces", ns)
+	}
+	segments = append(segments, gvr.Resource)
+
+	raw, err := rc.Get().
+		AbsPath(segments...).
+		VersionedParams(&o, metav1.ParameterCodec).
+		Param("includeObject", "Object").
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are kept as they were sent, not turned into floats
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	table := &metav1.Table{}
+	if err := decoder.Decode(table); err != nil {
+		return nil, err
+	}
+	if table.Kind != "Table" {
+		return nil, fmt.Errorf("the server doesn't print %s as a table", path.Join(segments...))
+	}
+	return table, nil
+}
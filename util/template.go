@@ -0,0 +1,47 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ParseTemplate - parse a -o go-template text. With fromFile, text is the
+// path of a file holding the template instead, like -o go-template-file
+func ParseTemplate(text string, fromFile bool) (*template.Template, error) {
+	name := "go-template"
+	if fromFile {
+		data, err := ioutil.ReadFile(text)
+		if err != nil {
+			return nil, fmt.Errorf("reading go-template-file: %v", err)
+		}
+		name, text = text, string(data)
+	}
+	if text == "" {
+		return nil, fmt.Errorf("go-template format specified but no template given")
+	}
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing go-template: %v", err)
+	}
+	return tmpl, nil
+}
+
+// PrintTemplate - render every object through tmpl, against the same fields
+// as its json output, e.g. {{.metadata.name}}
+func PrintTemplate(w io.Writer, tmpl *template.Template, objects []runtime.Object) error {
+	for _, obj := range objects {
+		setKind(obj)
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(w, data); err != nil {
+			return fmt.Errorf("error executing go-template: %v", err)
+		}
+	}
+	return nil
+}
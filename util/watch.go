@@ -0,0 +1,248 @@
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/mateo1647/kk/internal/options"
+)
+
+// watchFunc - open a watch on a single namespace
+type watchFunc func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error)
+
+type watcher struct {
+	namespaced bool
+	watch      watchFunc
+}
+
+// watchers - the resources that can be watched, keyed by their plural name
+var watchers = map[string]watcher{
+	"pods": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().Pods(ns).Watch(o)
+	}},
+	"deployments": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.AppsV1().Deployments(ns).Watch(o)
+	}},
+	"daemonsets": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.AppsV1().DaemonSets(ns).Watch(o)
+	}},
+	"statefulsets": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.AppsV1().StatefulSets(ns).Watch(o)
+	}},
+	"replicasets": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.AppsV1().ReplicaSets(ns).Watch(o)
+	}},
+	"replicationcontrollers": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().ReplicationControllers(ns).Watch(o)
+	}},
+	"jobs": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.BatchV1().Jobs(ns).Watch(o)
+	}},
+	"cronjobs": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.BatchV1beta1().CronJobs(ns).Watch(o)
+	}},
+	"persistentvolumeclaims": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().PersistentVolumeClaims(ns).Watch(o)
+	}},
+	"persistentvolumes": {false, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().PersistentVolumes().Watch(o)
+	}},
+	"storageclasses": {false, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.StorageV1().StorageClasses().Watch(o)
+	}},
+	"volumeattachments": {false, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.StorageV1().VolumeAttachments().Watch(o)
+	}},
+	"serviceaccounts": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().ServiceAccounts(ns).Watch(o)
+	}},
+	"roles": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.RbacV1().Roles(ns).Watch(o)
+	}},
+	"rolebindings": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.RbacV1().RoleBindings(ns).Watch(o)
+	}},
+	"clusterroles": {false, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.RbacV1().ClusterRoles().Watch(o)
+	}},
+	"clusterrolebindings": {false, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.RbacV1().ClusterRoleBindings().Watch(o)
+	}},
+	"configmaps": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().ConfigMaps(ns).Watch(o)
+	}},
+	"secrets": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().Secrets(ns).Watch(o)
+	}},
+	"networkpolicies": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.NetworkingV1().NetworkPolicies(ns).Watch(o)
+	}},
+	"endpoints": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().Endpoints(ns).Watch(o)
+	}},
+	"nodes": {false, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().Nodes().Watch(o)
+	}},
+	"resourcequotas": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().ResourceQuotas(ns).Watch(o)
+	}},
+	"limitranges": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().LimitRanges(ns).Watch(o)
+	}},
+	"events": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().Events(ns).Watch(o)
+	}},
+	"horizontalpodautoscalers": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		w, err := clientset.AutoscalingV2beta2().HorizontalPodAutoscalers(ns).Watch(o)
+		if !apierrors.IsNotFound(err) {
+			return w, err
+		}
+		return clientset.AutoscalingV1().HorizontalPodAutoscalers(ns).Watch(o)
+	}},
+	"namespaces": {false, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		return clientset.CoreV1().Namespaces().Watch(o)
+	}},
+	"ingresses": {true, func(clientset kubernetes.Interface, ns string, o metav1.ListOptions) (watch.Interface, error) {
+		w, err := clientset.NetworkingV1beta1().Ingresses(ns).Watch(o)
+		if !apierrors.IsNotFound(err) {
+			return w, err
+		}
+		return clientset.ExtensionsV1beta1().Ingresses(ns).Watch(o)
+	}},
+}
+
+// Watch - watch resource in every namespace resolved from opt and call
+// onEvent for each add, modify and delete. Disconnected watches are
+// re-established from the last resource version they delivered, and
+// transient failures retried. Watch only returns when the resource can't be
+// watched, e.g. it is forbidden or not found. When several namespaces are
+// watched the ones the user isn't allowed to watch are skipped, like lists.
+func (c *Client) Watch(opt *options.SearchOptions, resource string, onEvent func(watch.Event)) error {
+	return c.watch(opt, resource, false, onEvent)
+}
+
+// WatchChanges - like Watch, without the objects that already exist being
+// delivered as added first: every namespace is listed, and watched from the
+// resource version of the list
+func (c *Client) WatchChanges(opt *options.SearchOptions, resource string, onEvent func(watch.Event)) error {
+	return c.watch(opt, resource, true, onEvent)
+}
+
+func (c *Client) watch(opt *options.SearchOptions, resource string, fromNow bool, onEvent func(watch.Event)) error {
+	w, ok := watchers[resource]
+	if !ok {
+		// anything without a typed watcher goes through the dynamic client
+		var err error
+		if w, err = c.dynamicWatcher(resource); err != nil {
+			return fmt.Errorf("watching %s is not supported: %v", resource, err)
+		}
+	}
+
+	namespaces, o := c.SetOptions(opt)
+	// pagination only applies to lists
+	o.Limit = 0
+	if !w.namespaced {
+		namespaces = []string{""}
+	}
+	var version resourceVersionFunc
+	if fromNow {
+		var err error
+		if version, err = c.resourceVersion(resource); err != nil {
+			return err
+		}
+	}
+
+	var mu sync.Mutex
+	errs := make(chan error, len(namespaces))
+	for _, ns := range namespaces {
+		go func(ns string, o metav1.ListOptions) {
+			if version != nil {
+				rv, err := version(ns, o)
+				if err != nil {
+					errs <- err
+					return
+				}
+				o.ResourceVersion = rv
+			}
+			errs <- watchNamespace(c.Clientset, w.watch, ns, o, func(ev watch.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				onEvent(ev)
+			})
+		}(ns, *o)
+	}
+
+	var err error
+	for range namespaces {
+		if err = <-errs; len(namespaces) > 1 && apierrors.IsForbidden(err) {
+			continue
+		}
+		return err
+	}
+	return err
+}
+
+// watchNamespace - keep a watch open on ns, resuming from the last seen
+// resource version whenever the server closes it. It only returns when the
+// watch fails with an error that retrying won't fix.
+func watchNamespace(clientset kubernetes.Interface, open watchFunc, ns string, o metav1.ListOptions, onEvent func(watch.Event)) error {
+	backoff := time.Second
+	for {
+		w, err := open(clientset, ns, o)
+		if err != nil {
+			expired := apierrors.IsGone(err) || apierrors.IsResourceExpired(err)
+			if !expired && !IsTransient(err) {
+				log.WithFields(log.Fields{
+					"namespace": ns,
+					"err":       err.Error(),
+				}).Debug("Unable to start watch")
+				return err
+			}
+			log.WithFields(log.Fields{
+				"namespace": ns,
+				"err":       err.Error(),
+			}).Debug("Unable to start watch, retrying")
+			if expired {
+				o.ResourceVersion = ""
+			}
+			time.Sleep(backoff)
+			if backoff < 30*time.Second {
+				backoff *= 2
+			}
+			continue
+		}
+		backoff = time.Second
+
+		for ev := range w.ResultChan() {
+			if ev.Type == watch.Error {
+				err := apierrors.FromObject(ev.Object)
+				log.WithFields(log.Fields{
+					"namespace": ns,
+					"err":       err.Error(),
+				}).Debug("Watch failed")
+				if apierrors.IsGone(err) || apierrors.IsResourceExpired(err) {
+					// our resource version is too old, start over from now
+					o.ResourceVersion = ""
+				}
+				break
+			}
+			if accessor, err := meta.Accessor(ev.Object); err == nil {
+				o.ResourceVersion = accessor.GetResourceVersion()
+			}
+			onEvent(ev)
+		}
+		w.Stop()
+		log.WithFields(log.Fields{
+			"namespace":       ns,
+			"resourceVersion": o.ResourceVersion,
+		}).Debug("Watch closed, re-establishing")
+	}
+}
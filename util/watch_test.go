@@ -0,0 +1,44 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mateo1647/kk/internal/options"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWatchReturnsOnForbidden(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+	}{
+		{name: "one namespace", namespaces: []string{"team-a"}},
+		{name: "every namespace forbidden", namespaces: []string{"team-a", "team-b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := seedCluster(t, "default")
+			clientset.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+				return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+			})
+
+			done := make(chan error, 1)
+			go func() {
+				opt := &options.SearchOptions{Namespaces: tt.namespaces}
+				done <- ClientFor(opt).Watch(opt, "pods", func(watch.Event) {})
+			}()
+			select {
+			case err := <-done:
+				if !apierrors.IsForbidden(err) {
+					t.Errorf("Watch() error = %v, want Forbidden", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Watch() kept retrying a forbidden watch")
+			}
+		})
+	}
+}